@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+type AuditsAnswerCmd struct {
+	AuditID  string   `arg:"" help:"Audit ID (human ID like '708739' or full CouchDB ID)"`
+	Database string   `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	Category string   `help:"Category name or number (1-based)"`
+	Question string   `short:"q" help:"Question text or number within the category (1-based)"`
+	Value    []string `short:"V" help:"Answer value; repeat or separate with ';' for multiple choice options"`
+	Clear    bool     `help:"Clear the answer"`
+	File     string   `short:"f" type:"existingfile" help:"CSV or YAML file with answers (columns/keys: category, question, value)"`
+	DryRun   bool     `help:"Validate the answers without saving them"`
+	JSON     bool     `short:"j" help:"Output as JSON"`
+}
+
+func (c *AuditsAnswerCmd) Run(client *api.Client) error {
+	answers, err := c.answers()
+	if err != nil {
+		return err
+	}
+
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
+	}
+
+	var results []api.AnswerResult
+	if c.DryRun {
+		results, err = client.CheckAuditAnswers(database, auditID, answers)
+	} else {
+		results, err = client.AnswerAuditQuestions(database, auditID, answers)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		return printJSON(results)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CATEGORY\tQUESTION\tOLD\tNEW")
+	fmt.Fprintln(w, "--------\t--------\t---\t---")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			truncate(r.Category, 25),
+			truncate(r.Question, 40),
+			answerOrDash(r.OldAnswer),
+			answerOrDash(r.Answer))
+	}
+	w.Flush()
+
+	if c.DryRun {
+		fmt.Printf("\nDry run: %d answers valid, nothing saved.\n", len(results))
+	} else {
+		fmt.Printf("\n%d answers saved on audit %s.\n", len(results), humanID(auditID))
+	}
+	return nil
+}
+
+// answers builds the answers from either the flags or the answers file
+func (c *AuditsAnswerCmd) answers() ([]api.AnswerInput, error) {
+	single := c.Category != "" || c.Question != "" || len(c.Value) > 0 || c.Clear
+
+	if c.File != "" {
+		if single {
+			return nil, fmt.Errorf("--file cannot be combined with --category, --question, --value or --clear")
+		}
+		return api.LoadAnswersFile(c.File)
+	}
+
+	if c.Category == "" || c.Question == "" {
+		return nil, fmt.Errorf("use --category and --question with --value or --clear, or --file")
+	}
+	if c.Clear == (len(c.Value) > 0) {
+		return nil, fmt.Errorf("use either --value or --clear")
+	}
+
+	return []api.AnswerInput{{
+		Category: c.Category,
+		Question: c.Question,
+		Values:   c.Value,
+		Clear:    c.Clear,
+	}}, nil
+}
+
+func answerOrDash(answer []interface{}) string {
+	if s := formatAnswer(answer); s != "" {
+		return truncate(s, 30)
+	}
+	return "-"
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+	"github.com/dutchview/edcontrols-cli/internal/report"
+)
+
+type AuditsDiffCmd struct {
+	AuditA          string  `arg:"" name:"audit-a" help:"Earlier audit (human ID or full CouchDB ID)"`
+	AuditB          string  `arg:"" name:"audit-b" help:"Later audit (human ID or full CouchDB ID)"`
+	Database        string  `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	All             bool    `short:"a" help:"Also show unchanged answers"`
+	RatingThreshold float64 `default:"3" help:"Ratings below this value are findings (0 to leave ratings out)"`
+	JSON            bool    `short:"j" help:"Output as JSON"`
+}
+
+// auditDiffOutput is the JSON output of audits diff
+type auditDiffOutput struct {
+	AuditA string `json:"auditA"`
+	AuditB string `json:"auditB"`
+	api.AuditDiff
+}
+
+func (c *AuditsDiffCmd) Run(client *api.Client) error {
+	before, err := c.getAudit(client, c.AuditA)
+	if err != nil {
+		return err
+	}
+	after, err := c.getAudit(client, c.AuditB)
+	if err != nil {
+		return err
+	}
+
+	if before.Template != "" && after.Template != "" && before.Template != after.Template {
+		fmt.Fprintln(os.Stderr, "Warning: the audits use different templates; questions are matched by category and question text")
+	}
+
+	diff := api.DiffAudits(before, after, c.RatingThreshold)
+
+	if !c.All {
+		changes := diff.Changes[:0]
+		for _, change := range diff.Changes {
+			if change.Change != api.ChangeUnchanged {
+				changes = append(changes, change)
+			}
+		}
+		diff.Changes = changes
+	}
+
+	if c.JSON {
+		return printJSON(auditDiffOutput{AuditA: before.CouchDbID, AuditB: after.CouchDbID, AuditDiff: diff})
+	}
+
+	fmt.Printf("Comparing audit %s (%s) with %s (%s)\n\n", humanID(before.CouchDbID), before.Name, humanID(after.CouchDbID), after.Name)
+
+	if len(diff.Changes) == 0 {
+		fmt.Println("No changed answers.")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "CATEGORY\tQUESTION\tBEFORE\tAFTER\tCHANGE")
+		fmt.Fprintln(w, "--------\t--------\t------\t-----\t------")
+		for _, change := range diff.Changes {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", truncate(change.Category, 25), truncate(change.Question, 40),
+				textOrDash(change.OldAnswer), textOrDash(change.NewAnswer), change.Change)
+		}
+		w.Flush()
+	}
+
+	fmt.Printf("\nFixed: %d, newly failed: %d, changed: %d, added: %d, removed: %d\n",
+		diff.Counts[api.ChangeFixed], diff.Counts[api.ChangeFailed], diff.Counts[api.ChangeChanged],
+		diff.Counts[api.ChangeAdded], diff.Counts[api.ChangeRemoved])
+	fmt.Printf("Compliance: %s -> %s\n", report.ComplianceText(diff.Before), report.ComplianceText(diff.After))
+	return nil
+}
+
+func (c *AuditsDiffCmd) getAudit(client *api.Client, id string) (*api.Audit, error) {
+	database, auditID, err := resolveAuditID(client, c.Database, id)
+	if err != nil {
+		return nil, err
+	}
+	audit, err := client.GetAudit(database, auditID)
+	if err != nil {
+		return nil, fmt.Errorf("getting audit %s: %w", id, err)
+	}
+	if audit.CouchDbID == "" {
+		audit.CouchDbID = auditID
+	}
+	return audit, nil
+}
+
+func textOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return truncate(s, 30)
+}
@@ -2,21 +2,38 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/dutchview/edcontrols-cli/internal/api"
 )
 
 type AuditsCmd struct {
-	List        AuditsListCmd        `cmd:"" help:"List audits"`
-	Get         AuditsGetCmd         `cmd:"" help:"Get audit details"`
-	Create      AuditsCreateCmd      `cmd:"" help:"Create an audit from a template"`
-	Update      AuditsUpdateCmd      `cmd:"" help:"Update an existing audit"`
-	Delete      AuditsDeleteCmd      `cmd:"" help:"Delete an audit"`
-	Attachments AuditAttachmentsCmd  `cmd:"" help:"List or download audit attachments (photos)"`
+	List         AuditsListCmd         `cmd:"" help:"List audits"`
+	Get          AuditsGetCmd          `cmd:"" help:"Get audit details"`
+	Create       AuditsCreateCmd       `cmd:"" help:"Create an audit from a template"`
+	Update       AuditsUpdateCmd       `cmd:"" help:"Update an existing audit"`
+	Answer       AuditsAnswerCmd       `cmd:"" help:"Answer audit questions (--category, --question, --value) or load answers from a CSV/YAML file"`
+	Report       AuditsReportCmd       `cmd:"" help:"Export an audit report as PDF or HTML with photos and scores"`
+	Stats        AuditsStatsCmd        `cmd:"" help:"Show compliance scores, averages and trends for one or more audits"`
+	Findings     AuditsFindingsCmd     `cmd:"" help:"List failed answers and create follow-up tickets for them (--create-tickets)"`
+	Diff         AuditsDiffCmd         `cmd:"" help:"Compare the answers of two audits (fixed, newly failed and changed items)"`
+	Export       AuditsExportCmd       `cmd:"" help:"Export the answers of all audits of a template as CSV or XLSX (one row per audit)"`
+	Import       AuditsImportCmd       `cmd:"" help:"Import answers from an answer-matrix CSV, updating or creating one audit per row"`
+	Start        AuditsStartCmd        `cmd:"" help:"Mark an audit as in progress"`
+	Complete     AuditsCompleteCmd     `cmd:"" help:"Complete an audit (checks that required questions are answered, --force to skip)"`
+	Reopen       AuditsReopenCmd       `cmd:"" help:"Reopen a completed audit"`
+	Schedule     AuditsScheduleCmd     `cmd:"" help:"Create recurring audits from a schedule file (run -f schedule.yaml)"`
+	Delete       AuditsDeleteCmd       `cmd:"" help:"Delete an audit"`
+	Attachments  AuditAttachmentsCmd   `cmd:"" help:"List or download audit attachments (photos)"`
+	Participants AuditsParticipantsCmd `cmd:"" help:"Show or change informed and consulted participants (--inform, --consult, --remove)"`
+	Tags         AuditsTagsCmd         `cmd:"" help:"Show or change audit tags (--add, --remove, --set, --clear)"`
+	History      AuditsHistoryCmd      `cmd:"" help:"Show the change history of an audit (--author, --property, --platform, -f table|json|diff)"`
 }
 
 type AuditsListCmd struct {
@@ -42,34 +59,9 @@ type AuditsListCmd struct {
 
 func (c *AuditsListCmd) Run(client *api.Client) error {
 	// Parse date filters
-	var filters DateFilterSet
-	if c.CreatedAfter != "" {
-		t, err := ParseRelativeTime(c.CreatedAfter)
-		if err != nil {
-			return fmt.Errorf("--created-after: %w", err)
-		}
-		filters.CreatedAfter = &t
-	}
-	if c.CreatedBefore != "" {
-		t, err := ParseRelativeTime(c.CreatedBefore)
-		if err != nil {
-			return fmt.Errorf("--created-before: %w", err)
-		}
-		filters.CreatedBefore = &t
-	}
-	if c.ModifiedAfter != "" {
-		t, err := ParseRelativeTime(c.ModifiedAfter)
-		if err != nil {
-			return fmt.Errorf("--modified-after: %w", err)
-		}
-		filters.ModifiedAfter = &t
-	}
-	if c.ModifiedBefore != "" {
-		t, err := ParseRelativeTime(c.ModifiedBefore)
-		if err != nil {
-			return fmt.Errorf("--modified-before: %w", err)
-		}
-		filters.ModifiedBefore = &t
+	filters, err := ParseDateFilters(c.CreatedAfter, c.CreatedBefore, c.ModifiedAfter, c.ModifiedBefore)
+	if err != nil {
+		return err
 	}
 
 	hasDateFilters := filters.HasDateFilters()
@@ -217,6 +209,7 @@ func (c *AuditsListCmd) Run(client *api.Client) error {
 			if err != nil {
 				continue // Skip projects with errors
 			}
+			rememberAudits(project.ProjectID, audits)
 
 			// Track which project each audit belongs to and apply date filter
 			for _, a := range audits {
@@ -256,6 +249,10 @@ func (c *AuditsListCmd) Run(client *api.Client) error {
 		total = len(allAudits)
 	}
 
+	if c.Database != "" {
+		rememberAudits(c.Database, allAudits)
+	}
+
 	if c.JSON {
 		return printJSON(allAudits)
 	}
@@ -323,24 +320,9 @@ type AuditsGetCmd struct {
 }
 
 func (c *AuditsGetCmd) Run(client *api.Client) error {
-	database := c.Database
-	auditID := c.AuditID
-
-	// If the audit ID looks like a human ID (6 chars or less), search for it
-	isHumanID := len(c.AuditID) <= 6
-
-	if isHumanID {
-		// Search for the audit by human ID
-		var searchDB string
-		if c.Database != "" {
-			searchDB = c.Database
-		}
-		foundDB, foundID, err := findAuditByHumanID(client, c.AuditID, searchDB)
-		if err != nil {
-			return err
-		}
-		database = foundDB
-		auditID = foundID
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
 	}
 
 	if c.JSON {
@@ -356,6 +338,7 @@ func (c *AuditsGetCmd) Run(client *api.Client) error {
 	if err != nil {
 		return err
 	}
+	rememberAudits(database, []api.Audit{*audit})
 
 	fmt.Printf("Audit: %s\n", audit.Name)
 	fmt.Printf("ID: %s (%s)\n", humanID(auditID), auditID)
@@ -391,6 +374,12 @@ func (c *AuditsGetCmd) Run(client *api.Client) error {
 	if audit.Participants != nil && audit.Participants.Responsible != nil && audit.Participants.Responsible.Email != "" {
 		fmt.Printf("Responsible: %s\n", audit.Participants.Responsible.Email)
 	}
+	if audit.Participants != nil && len(audit.Participants.Informed) > 0 {
+		fmt.Printf("Informed: %s\n", personEmailList(audit.Participants.Informed))
+	}
+	if audit.Participants != nil && len(audit.Participants.Consulted) > 0 {
+		fmt.Printf("Consulted: %s\n", personEmailList(audit.Participants.Consulted))
+	}
 	if audit.Author != nil && audit.Author.Email != "" {
 		fmt.Printf("Author: %s\n", audit.Author.Email)
 	}
@@ -462,7 +451,7 @@ func formatAnswer(answer []interface{}) string {
 				parts = append(parts, "No")
 			}
 		case float64:
-			parts = append(parts, fmt.Sprintf("%.0f", v))
+			parts = append(parts, strconv.FormatFloat(v, 'f', -1, 64))
 		}
 	}
 
@@ -472,57 +461,6 @@ func formatAnswer(answer []interface{}) string {
 	return strings.Join(parts, ", ")
 }
 
-// findAuditByHumanID searches for an audit matching the given human ID.
-// If limitToDatabase is not empty, only searches that database.
-// Returns the database name and full CouchDB ID of the audit.
-func findAuditByHumanID(client *api.Client, searchID string, limitToDatabase string) (string, string, error) {
-	searchID = strings.ToUpper(searchID)
-
-	var projectIDs []string
-
-	if limitToDatabase != "" {
-		projectIDs = []string{limitToDatabase}
-	} else {
-		projects, _, err := client.ListProjects(api.ListProjectsOptions{})
-		if err != nil {
-			return "", "", err
-		}
-		for _, project := range projects {
-			// Skip glacier projects and inactive projects for faster search
-			if project.ProjectID == "glacier_project_documents" || !project.IsActive {
-				continue
-			}
-			projectIDs = append(projectIDs, project.ProjectID)
-		}
-	}
-
-	// Use the POST search endpoint which supports searchById across multiple projects
-	audits, err := client.SearchAuditsByID(projectIDs, strings.ToLower(searchID))
-	if err != nil {
-		return "", "", err
-	}
-
-	// Verify the human ID matches and extract the database from the audit
-	for _, audit := range audits {
-		if humanID(audit.CouchDbID) == searchID {
-			// Extract database from the audit's ID field (format: database|couchDbId)
-			if audit.ID != "" && strings.Contains(audit.ID, "|") {
-				parts := strings.SplitN(audit.ID, "|", 2)
-				return parts[0], audit.CouchDbID, nil
-			}
-			// Fallback: search each project to find where this audit exists
-			for _, projectID := range projectIDs {
-				_, err := client.GetAudit(projectID, audit.CouchDbID)
-				if err == nil {
-					return projectID, audit.CouchDbID, nil
-				}
-			}
-		}
-	}
-
-	return "", "", fmt.Errorf("audit with ID %s not found", searchID)
-}
-
 type AuditsCreateCmd struct {
 	Database    string   `arg:"" name:"project-id" help:"Project ID"`
 	TemplateID  string   `arg:"" help:"Audit template ID to use"`
@@ -545,6 +483,7 @@ func (c *AuditsCreateCmd) Run(client *api.Client) error {
 	if err != nil {
 		return err
 	}
+	rememberAudits(c.Database, []api.Audit{*audit})
 
 	if c.JSON {
 		return printJSON(audit)
@@ -559,48 +498,47 @@ func (c *AuditsCreateCmd) Run(client *api.Client) error {
 }
 
 type AuditsUpdateCmd struct {
-	AuditID       string   `arg:"" help:"Audit ID (human ID like '708739' or full CouchDB ID)"`
-	Database      string   `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
-	Name          string   `short:"n" help:"New audit name"`
-	Tags          []string `short:"t" help:"Set tags (replaces existing tags, can be specified multiple times)"`
-	QuestionsFile string   `short:"q" name:"questions-file" help:"Path to JSON file containing the questions array"`
-	Maps          []string `short:"m" help:"Set map IDs (replaces existing maps, can be specified multiple times)"`
-	JSON          bool     `short:"j" help:"Output as JSON"`
+	AuditID           string   `arg:"" help:"Audit ID (human ID like '708739' or full CouchDB ID)"`
+	Database          string   `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	Name              string   `short:"n" help:"New audit name"`
+	Tags              []string `short:"t" help:"Set tags (replaces existing tags, can be specified multiple times)"`
+	QuestionsFile     string   `short:"q" name:"questions-file" help:"Path to JSON file containing the questions array"`
+	Maps              []string `short:"m" help:"Set map IDs (replaces existing maps, can be specified multiple times)"`
+	Responsible       string   `short:"r" help:"Responsible person email"`
+	ClearResponsible  bool     `help:"Clear the responsible person"`
+	DueDate           string   `short:"d" help:"Due date (e.g., 3d, 2w, 1mo from now, 2026-03-15, or ISO 8601)"`
+	ClearDue          bool     `help:"Clear the due date"`
+	Inform            []string `short:"i" help:"Email to add to the informed participants (can be specified multiple times)"`
+	Consult           []string `help:"Email to add to the consulted participants (can be specified multiple times)"`
+	RemoveParticipant []string `help:"Email to remove from the informed and consulted participants (can be specified multiple times)"`
+	Comment           string   `help:"Add a comment to the audit"`
+	JSON              bool     `short:"j" help:"Output as JSON"`
 }
 
 func (c *AuditsUpdateCmd) Run(client *api.Client) error {
-	database := c.Database
-	auditID := c.AuditID
-
-	// If the audit ID looks like a human ID (6 chars or less), search for it
-	isHumanID := len(c.AuditID) <= 6
-	if isHumanID {
-		var searchDB string
-		if c.Database != "" {
-			searchDB = c.Database
-		}
-		foundDB, foundID, err := findAuditByHumanID(client, c.AuditID, searchDB)
-		if err != nil {
-			return err
-		}
-		database = foundDB
-		auditID = foundID
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
 	}
 
-	updates := make(map[string]interface{})
-
+	fields := api.UpdateAuditFieldsOptions{
+		ClearResponsible: c.ClearResponsible,
+		ClearDue:         c.ClearDue,
+		Participants: api.ParticipantsEdit{
+			Inform:  c.Inform,
+			Consult: c.Consult,
+			Remove:  c.RemoveParticipant,
+		},
+	}
 	if c.Name != "" {
-		updates["name"] = c.Name
+		fields.Name = &c.Name
 	}
-
 	if len(c.Tags) > 0 {
-		updates["tags"] = c.Tags
+		fields.Tags = c.Tags
 	}
-
 	if len(c.Maps) > 0 {
-		updates["maps"] = c.Maps
+		fields.Maps = c.Maps
 	}
-
 	if c.QuestionsFile != "" {
 		data, err := os.ReadFile(c.QuestionsFile)
 		if err != nil {
@@ -610,15 +548,31 @@ func (c *AuditsUpdateCmd) Run(client *api.Client) error {
 		if err := json.Unmarshal(data, &questions); err != nil {
 			return fmt.Errorf("parsing questions JSON: %w", err)
 		}
-		updates["questions"] = questions
+		fields.Questions = questions
+	}
+	if c.Responsible != "" {
+		fields.Responsible = &c.Responsible
+	}
+	if c.DueDate != "" {
+		due, err := ParseDueDate(c.DueDate, time.Now())
+		if err != nil {
+			return fmt.Errorf("--due-date: %w", err)
+		}
+		fields.DueDate = &due
+	}
+	if c.Comment != "" {
+		// Sanitize HTML in comment to prevent XSS
+		sanitized := sanitizeHTML(c.Comment)
+		fields.Comment = &sanitized
 	}
 
-	if len(updates) == 0 {
-		return fmt.Errorf("no updates specified (use --name, --tags, --questions-file, or --maps)")
+	if fields.IsEmpty() {
+		return fmt.Errorf("no updates specified (use --name, --tags, --questions-file, --maps, --responsible, --due-date, --inform, --consult, --remove-participant, or --comment)")
 	}
 
-	if err := client.UpdateAudit(database, auditID, updates); err != nil {
-		return err
+	changed, err := client.UpdateAuditFields(database, auditID, fields)
+	if err != nil {
+		return fmt.Errorf("updating audit: %w", err)
 	}
 
 	if c.JSON {
@@ -630,7 +584,74 @@ func (c *AuditsUpdateCmd) Run(client *api.Client) error {
 		return printJSON(doc)
 	}
 
+	if len(changed) == 0 {
+		fmt.Printf("Audit %s was already up to date.\n", humanID(auditID))
+		return nil
+	}
 	fmt.Printf("Audit %s updated successfully.\n", humanID(auditID))
+	if fields.DueDate != nil {
+		fmt.Printf("Due date: %s\n", *fields.DueDate)
+	}
+	return nil
+}
+
+type AuditsStartCmd struct {
+	AuditID  string `arg:"" help:"Audit ID (human ID like '708739' or full CouchDB ID)"`
+	Database string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	JSON     bool   `short:"j" help:"Output as JSON"`
+}
+
+func (c *AuditsStartCmd) Run(client *api.Client) error {
+	return transitionAudit(client, c.Database, c.AuditID, api.AuditStart, false, c.JSON)
+}
+
+type AuditsCompleteCmd struct {
+	AuditID  string `arg:"" help:"Audit ID (human ID like '708739' or full CouchDB ID)"`
+	Database string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	Force    bool   `help:"Complete even if required questions are not answered"`
+	JSON     bool   `short:"j" help:"Output as JSON"`
+}
+
+func (c *AuditsCompleteCmd) Run(client *api.Client) error {
+	return transitionAudit(client, c.Database, c.AuditID, api.AuditComplete, c.Force, c.JSON)
+}
+
+type AuditsReopenCmd struct {
+	AuditID  string `arg:"" help:"Audit ID (human ID like '708739' or full CouchDB ID)"`
+	Database string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	JSON     bool   `short:"j" help:"Output as JSON"`
+}
+
+func (c *AuditsReopenCmd) Run(client *api.Client) error {
+	return transitionAudit(client, c.Database, c.AuditID, api.AuditReopen, false, c.JSON)
+}
+
+// transitionAudit changes the status of an audit and prints the result
+func transitionAudit(client *api.Client, database, id string, transition api.AuditTransition, force, asJSON bool) error {
+	database, auditID, err := resolveAuditID(client, database, id)
+	if err != nil {
+		return err
+	}
+
+	change, err := client.TransitionAudit(database, auditID, transition, force)
+	if err != nil {
+		var unanswered *api.UnansweredError
+		if errors.As(err, &unanswered) {
+			return fmt.Errorf("%w\nAnswer them with 'ec audits answer' or use --force", err)
+		}
+		return fmt.Errorf("changing audit status: %w", err)
+	}
+
+	if asJSON {
+		return printJSON(change)
+	}
+
+	verb := map[api.AuditTransition]string{
+		api.AuditStart:    "started",
+		api.AuditComplete: "completed",
+		api.AuditReopen:   "reopened",
+	}[transition]
+	fmt.Printf("Audit %s %s (status: %s -> %s)\n", humanID(auditID), verb, statusString(change.OldStatus), change.Status)
 	return nil
 }
 
@@ -640,21 +661,15 @@ type AuditsDeleteCmd struct {
 }
 
 func (c *AuditsDeleteCmd) Run(client *api.Client) error {
-	database := c.Database
-	auditID := c.AuditID
-
-	if len(c.AuditID) <= 6 {
-		foundDB, foundID, err := findAuditByHumanID(client, c.AuditID, c.Database)
-		if err != nil {
-			return err
-		}
-		database = foundDB
-		auditID = foundID
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
 	}
 
 	if err := client.DeleteAudits(database, []string{auditID}); err != nil {
 		return fmt.Errorf("deleting audit: %w", err)
 	}
+	forgetID(kindAudit, auditID)
 	fmt.Printf("Audit %s deleted.\n", humanID(auditID))
 	return nil
 }
@@ -664,6 +679,7 @@ func (c *AuditsDeleteCmd) Run(client *api.Client) error {
 type AuditAttachmentsCmd struct {
 	List     AuditAttachmentsListCmd     `cmd:"" help:"List attachments on an audit"`
 	Download AuditAttachmentsDownloadCmd `cmd:"" help:"Download attachments from an audit"`
+	Add      AuditAttachmentsAddCmd      `cmd:"" help:"Upload photos or documents to an audit"`
 }
 
 type AuditAttachmentsListCmd struct {
@@ -673,16 +689,9 @@ type AuditAttachmentsListCmd struct {
 }
 
 func (c *AuditAttachmentsListCmd) Run(client *api.Client) error {
-	database := c.Database
-	auditID := c.AuditID
-
-	if len(c.AuditID) <= 6 {
-		foundDB, foundID, err := findAuditByHumanID(client, c.AuditID, c.Database)
-		if err != nil {
-			return err
-		}
-		database = foundDB
-		auditID = foundID
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
 	}
 
 	doc, err := client.GetDocument(database, auditID)
@@ -717,16 +726,9 @@ func (c *AuditAttachmentsDownloadCmd) Run(client *api.Client) error {
 		return fmt.Errorf("specify an attachment name or use --all to download all attachments")
 	}
 
-	database := c.Database
-	auditID := c.AuditID
-
-	if len(c.AuditID) <= 6 {
-		foundDB, foundID, err := findAuditByHumanID(client, c.AuditID, c.Database)
-		if err != nil {
-			return err
-		}
-		database = foundDB
-		auditID = foundID
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
 	}
 
 	doc, err := client.GetDocument(database, auditID)
@@ -757,3 +759,33 @@ func (c *AuditAttachmentsDownloadCmd) Run(client *api.Client) error {
 
 	return downloadAttachment(client, database, auditID, c.Name, c.Output)
 }
+
+type AuditAttachmentsAddCmd struct {
+	Database string   `arg:"" name:"project-id" help:"Project ID"`
+	AuditID  string   `arg:"" help:"Audit ID (human ID or full CouchDB ID)"`
+	Files    []string `arg:"" name:"file" type:"existingfile" help:"Files to upload"`
+}
+
+func (c *AuditAttachmentsAddCmd) Run(client *api.Client) error {
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
+	}
+
+	return uploadAttachments(client, database, auditID, c.Files)
+}
+
+type AuditsTagsCmd struct {
+	AuditID      string `arg:"" help:"Audit ID (human ID like '708739' or full CouchDB ID)"`
+	Database     string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	TagEditFlags `embed:""`
+}
+
+func (c *AuditsTagsCmd) Run(client *api.Client) error {
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
+	}
+
+	return runTagEdit(client, database, auditID, "audit", humanID(auditID), c.edit())
+}
@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+// --- Bulk Ticket Operations ---
+
+type TicketsBulkCmd struct {
+	Assign     TicketsBulkAssignCmd     `cmd:"" help:"Assign all matching tickets to someone"`
+	Close      TicketsBulkCloseCmd      `cmd:"" help:"Close all matching tickets"`
+	Reopen     TicketsBulkReopenCmd     `cmd:"" help:"Reopen all matching tickets"`
+	Archive    TicketsBulkArchiveCmd    `cmd:"" help:"Archive all matching tickets"`
+	TagAdd     TicketsBulkTagAddCmd     `cmd:"" name:"tag-add" help:"Add tags to all matching tickets"`
+	TagReplace TicketsBulkTagReplaceCmd `cmd:"" name:"tag-replace" help:"Replace a tag on all matching tickets"`
+	Delete     TicketsBulkDeleteCmd     `cmd:"" help:"Delete all matching tickets"`
+}
+
+// TicketFilterFlags selects the tickets a bulk operation applies to.
+// The filters are the same as for 'tickets list', but a project is required.
+type TicketFilterFlags struct {
+	Database       string `arg:"" name:"project-id" help:"Project ID"`
+	Status         string `short:"s" enum:"created,started,completed," default:"" help:"Filter by status (created, started, completed)"`
+	Search         string `help:"Search by title"`
+	Responsible    string `short:"r" help:"Filter by responsible person email"`
+	Tag            string `short:"t" help:"Filter by tag"`
+	GroupID        string `short:"g" help:"Filter by group ID"`
+	Archived       bool   `short:"a" help:"Include archived tickets"`
+	CreatedAfter   string `help:"Only tickets created after this time (e.g., 2w, 3d, 1mo, 1y, or 2026-01-15)"`
+	CreatedBefore  string `help:"Only tickets created before this time (e.g., 2w, 3d, 1mo, 1y, or 2026-01-15)"`
+	ModifiedAfter  string `help:"Only tickets modified after this time (e.g., 2w, 3d, 1mo, 1y, or 2026-01-15)"`
+	ModifiedBefore string `help:"Only tickets modified before this time (e.g., 2w, 3d, 1mo, 1y, or 2026-01-15)"`
+	Limit          int    `short:"l" default:"1000" help:"Maximum number of tickets to process"`
+
+	excludeStatus string // Leave out tickets with this status when no status filter is given
+}
+
+// BulkFlags control how a bulk operation is executed
+type BulkFlags struct {
+	Yes       bool `short:"y" help:"Skip the confirmation prompt"`
+	DryRun    bool `help:"Only show the matching tickets, don't change anything"`
+	BatchSize int  `default:"50" help:"Number of tickets per bulk delete request"`
+}
+
+// bulkFunc applies a bulk operation to a batch of tickets and returns one result per ticket
+type bulkFunc func(client *api.Client, database string, tickets []api.Ticket) []api.BulkResult
+
+// perTicket returns a bulkFunc that updates the tickets one at a time, through the
+// same update path as the single-ticket commands
+func perTicket(update func(client *api.Client, database string, ticket api.Ticket) error) bulkFunc {
+	return func(client *api.Client, database string, tickets []api.Ticket) []api.BulkResult {
+		results := make([]api.BulkResult, 0, len(tickets))
+		for _, t := range tickets {
+			if err := update(client, database, t); err != nil {
+				results = append(results, api.BulkResult{ID: t.CouchDbID, Error: err.Error()})
+			} else {
+				results = append(results, api.BulkResult{ID: t.CouchDbID, OK: true})
+			}
+		}
+		return results
+	}
+}
+
+type TicketsBulkAssignCmd struct {
+	TicketFilterFlags `embed:""`
+	Email             string `arg:"" help:"Email of the new responsible person"`
+	BulkFlags         `embed:""`
+}
+
+func (c *TicketsBulkAssignCmd) Run(client *api.Client) error {
+	return runTicketsBulk(client, c.TicketFilterFlags, c.BulkFlags, "assigned to "+c.Email,
+		perTicket(func(client *api.Client, database string, t api.Ticket) error {
+			return client.UpdateTicketFields(database, t.CouchDbID, api.UpdateTicketFieldsOptions{Responsible: &c.Email})
+		}))
+}
+
+type TicketsBulkCloseCmd struct {
+	TicketFilterFlags `embed:""`
+	BulkFlags         `embed:""`
+}
+
+func (c *TicketsBulkCloseCmd) Run(client *api.Client) error {
+	// Completed tickets can't be closed again
+	c.excludeStatus = api.TicketCompleted
+	return runTicketsBulk(client, c.TicketFilterFlags, c.BulkFlags, "closed",
+		perTicket(func(client *api.Client, database string, t api.Ticket) error {
+			return client.UpdateTicketFields(database, t.CouchDbID, api.UpdateTicketFieldsOptions{Complete: true})
+		}))
+}
+
+type TicketsBulkReopenCmd struct {
+	TicketFilterFlags `embed:""`
+	BulkFlags         `embed:""`
+}
+
+func (c *TicketsBulkReopenCmd) Run(client *api.Client) error {
+	// Only completed tickets can be reopened
+	if c.Status == "" {
+		c.Status = api.TicketCompleted
+	}
+	return runTicketsBulk(client, c.TicketFilterFlags, c.BulkFlags, "reopened",
+		perTicket(func(client *api.Client, database string, t api.Ticket) error {
+			return client.UpdateTicketFields(database, t.CouchDbID, api.UpdateTicketFieldsOptions{Reopen: true})
+		}))
+}
+
+type TicketsBulkArchiveCmd struct {
+	TicketFilterFlags `embed:""`
+	BulkFlags         `embed:""`
+}
+
+func (c *TicketsBulkArchiveCmd) Run(client *api.Client) error {
+	return runTicketsBulk(client, c.TicketFilterFlags, c.BulkFlags, "archived",
+		perTicket(func(client *api.Client, database string, t api.Ticket) error {
+			return client.ArchiveTicket(database, t.CouchDbID, true)
+		}))
+}
+
+type TicketsBulkTagAddCmd struct {
+	TicketFilterFlags `embed:""`
+	Tags              []string `arg:"" name:"tag" help:"Tags to add"`
+	BulkFlags         `embed:""`
+}
+
+func (c *TicketsBulkTagAddCmd) Run(client *api.Client) error {
+	return runTicketsBulk(client, c.TicketFilterFlags, c.BulkFlags, "tagged with "+strings.Join(c.Tags, ", "),
+		perTicket(func(client *api.Client, database string, t api.Ticket) error {
+			_, err := client.EditDocumentTags(database, t.CouchDbID, api.TagEdit{Add: c.Tags})
+			return err
+		}))
+}
+
+type TicketsBulkTagReplaceCmd struct {
+	TicketFilterFlags `embed:""`
+	From              string `arg:"" help:"Tag to replace"`
+	To                string `arg:"" help:"Replacement tag"`
+	BulkFlags         `embed:""`
+}
+
+func (c *TicketsBulkTagReplaceCmd) Run(client *api.Client) error {
+	// Only tickets with the tag are retagged
+	if c.Tag == "" {
+		c.Tag = c.From
+	}
+	return runTicketsBulk(client, c.TicketFilterFlags, c.BulkFlags, fmt.Sprintf("retagged from '%s' to '%s'", c.From, c.To),
+		perTicket(func(client *api.Client, database string, t api.Ticket) error {
+			if !containsString(t.Tags, c.From) {
+				return fmt.Errorf("ticket has no tag '%s'", c.From)
+			}
+			_, err := client.EditDocumentTags(database, t.CouchDbID, api.TagEdit{Remove: []string{c.From}, Add: []string{c.To}})
+			return err
+		}))
+}
+
+type TicketsBulkDeleteCmd struct {
+	TicketFilterFlags `embed:""`
+	BulkFlags         `embed:""`
+}
+
+func (c *TicketsBulkDeleteCmd) Run(client *api.Client) error {
+	return runTicketsBulk(client, c.TicketFilterFlags, c.BulkFlags, "permanently deleted",
+		func(client *api.Client, database string, tickets []api.Ticket) []api.BulkResult {
+			ids := make([]string, 0, len(tickets))
+			for _, t := range tickets {
+				ids = append(ids, t.CouchDbID)
+			}
+			results, err := client.BulkDeleteTickets(database, ids)
+			if err != nil {
+				// The whole batch failed
+				results = make([]api.BulkResult, len(ids))
+				for i, id := range ids {
+					results[i] = api.BulkResult{ID: id, Error: err.Error()}
+				}
+			}
+			for _, r := range results {
+				if r.OK {
+					forgetID(kindTicket, r.ID)
+				}
+			}
+			return results
+		})
+}
+
+// runTicketsBulk finds the tickets matching the filters, shows a preview, asks for
+// confirmation and executes the operation in batches, reporting the result per ticket.
+// Deletes use the bulk endpoint; other operations update the tickets one by one.
+func runTicketsBulk(client *api.Client, filter TicketFilterFlags, flags BulkFlags, description string, run bulkFunc) error {
+	tickets, limitReached, err := findTickets(client, filter)
+	if err != nil {
+		return err
+	}
+
+	if len(tickets) == 0 {
+		fmt.Println("No tickets match the filters.")
+		return nil
+	}
+
+	printBulkPreview(tickets)
+	if limitReached {
+		fmt.Printf("\nLimit of %d tickets reached; more tickets may match. Use -l to process more.\n", filter.Limit)
+	}
+	fmt.Printf("\n%d tickets will be %s.\n", len(tickets), description)
+
+	if flags.DryRun {
+		fmt.Println("Dry run: no changes made.")
+		return nil
+	}
+
+	if !flags.Yes && !confirm("Continue?") {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	batchSize := flags.BatchSize
+	if batchSize < 1 {
+		batchSize = 50
+	}
+
+	var succeeded, failed, unknown int
+
+	fmt.Println()
+	for start := 0; start < len(tickets); start += batchSize {
+		end := start + batchSize
+		if end > len(tickets) {
+			end = len(tickets)
+		}
+
+		for _, r := range run(client, filter.Database, tickets[start:end]) {
+			switch {
+			case r.OK:
+				succeeded++
+				fmt.Printf("  %s  ok\n", humanID(r.ID))
+			case r.Unknown:
+				unknown++
+				fmt.Printf("  %s  UNKNOWN: %s\n", humanID(r.ID), r.Error)
+			default:
+				failed++
+				fmt.Printf("  %s  FAILED: %s\n", humanID(r.ID), r.Error)
+			}
+		}
+	}
+
+	fmt.Printf("\nDone: %d succeeded, %d failed, %d unknown\n", succeeded, failed, unknown)
+	if failed > 0 || unknown > 0 {
+		return fmt.Errorf("%d of %d tickets failed or were not confirmed", failed+unknown, len(tickets))
+	}
+	return nil
+}
+
+// findTickets pages through the tickets of a project matching the filters, up to the limit.
+// Returns whether the limit was reached.
+func findTickets(client *api.Client, filter TicketFilterFlags) ([]api.Ticket, bool, error) {
+	dates, err := ParseDateFilters(filter.CreatedAfter, filter.CreatedBefore, filter.ModifiedAfter, filter.ModifiedBefore)
+	if err != nil {
+		return nil, false, err
+	}
+
+	const pageSize = 200
+	var matched []api.Ticket
+	for page := 0; ; page++ {
+		tickets, _, err := client.ListTickets(api.ListTicketsOptions{
+			Database:    filter.Database,
+			Status:      filter.Status,
+			SearchTitle: filter.Search,
+			Responsible: filter.Responsible,
+			Tag:         filter.Tag,
+			GroupID:     filter.GroupID,
+			Archived:    filter.Archived,
+			Size:        pageSize,
+			Page:        page,
+			SortBy:      "CREATIONDATE",
+			SortOrder:   "ASC",
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("listing tickets: %w", err)
+		}
+		rememberTickets(filter.Database, tickets)
+
+		for _, t := range tickets {
+			if filter.Status == "" && filter.excludeStatus != "" && t.State != nil && t.State.State == filter.excludeStatus {
+				continue
+			}
+			if dates.HasDateFilters() {
+				created := ""
+				modified := ""
+				if t.Dates != nil {
+					created = t.Dates.CreationDate
+					modified = t.Dates.LastModified
+				}
+				if !dates.MatchesDates(created, modified) {
+					continue
+				}
+			}
+			if len(matched) >= filter.Limit {
+				return matched, true, nil
+			}
+			matched = append(matched, t)
+		}
+
+		if len(tickets) < pageSize {
+			return matched, false, nil
+		}
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func printBulkPreview(tickets []api.Ticket) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "HUMAN_ID\tTITLE\tSTATUS\tASSIGNED")
+	fmt.Fprintln(w, "--------\t-----\t------\t--------")
+
+	for _, ticket := range tickets {
+		title := "-"
+		if ticket.Content != nil && ticket.Content.Title != "" {
+			title = truncate(ticket.Content.Title, 40)
+		}
+
+		status := "-"
+		if ticket.State != nil && ticket.State.State != "" {
+			status = ticket.State.State
+		}
+
+		assigned := "-"
+		if ticket.Participants != nil && ticket.Participants.Responsible != nil && ticket.Participants.Responsible.Email != "" {
+			assigned = truncate(ticket.Participants.Responsible.Email, 25)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", humanID(ticket.CouchDbID), title, status, assigned)
+	}
+
+	w.Flush()
+}
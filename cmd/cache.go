@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+	"github.com/dutchview/edcontrols-cli/internal/idcache"
+)
+
+// CacheCmd manages the local ID cache
+type CacheCmd struct {
+	Clear CacheClearCmd `cmd:"" help:"Remove all entries from the ID cache"`
+	Stats CacheStatsCmd `cmd:"" help:"Show ID cache statistics"`
+}
+
+// CacheClearCmd removes the ID cache file
+type CacheClearCmd struct{}
+
+func (c *CacheClearCmd) Run() error {
+	cache := idcache.Open(idcache.DefaultPath(), idcache.DefaultTTL)
+	if err := cache.Clear(); err != nil {
+		return err
+	}
+	fmt.Println("ID cache cleared.")
+	return nil
+}
+
+// CacheStatsCmd shows what the ID cache contains
+type CacheStatsCmd struct {
+	JSON bool `short:"j" help:"Output as JSON"`
+}
+
+func (c *CacheStatsCmd) Run() error {
+	stats := idcache.Open(idcache.DefaultPath(), idcache.DefaultTTL).Stats()
+
+	if c.JSON {
+		return printJSON(stats)
+	}
+
+	fmt.Printf("Path:    %s\n", stats.Path)
+	fmt.Printf("Size:    %d bytes\n", stats.SizeBytes)
+	fmt.Printf("TTL:     %s\n", stats.TTL)
+	fmt.Printf("Entries: %d\n", stats.Entries)
+
+	kinds := make([]string, 0, len(stats.ByKind))
+	for kind := range stats.ByKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		fmt.Printf("  %-7s %d\n", kind+":", stats.ByKind[kind])
+	}
+
+	if stats.Expired > 0 {
+		fmt.Printf("Expired: %d\n", stats.Expired)
+	}
+	if stats.Oldest != nil {
+		fmt.Printf("Oldest:  %s\n", stats.Oldest.Local().Format("2006-01-02 15:04"))
+		fmt.Printf("Newest:  %s\n", stats.Newest.Local().Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+var (
+	idCache         *idcache.Cache
+	idCacheDisabled bool
+	idCacheChanged  bool
+)
+
+// DisableIDCache turns off reading and writing the local ID cache
+func DisableIDCache() {
+	idCacheDisabled = true
+}
+
+// cachedIDs returns the ID cache, loading it on first use. Returns nil when disabled.
+func cachedIDs() *idcache.Cache {
+	if idCacheDisabled {
+		return nil
+	}
+	if idCache == nil {
+		idCache = idcache.Open(idcache.DefaultPath(), idcache.DefaultTTL)
+	}
+	return idCache
+}
+
+// SaveIDCache writes the ID cache to disk if the command changed it. The cache is
+// best-effort, so write errors are ignored.
+func SaveIDCache() {
+	if idCache == nil || !idCacheChanged {
+		return
+	}
+	_ = idCache.Save()
+	idCacheChanged = false
+}
+
+// rememberIDs records where documents live. The cache is written once the command
+// is done, see SaveIDCache.
+func rememberIDs(kind docKind, entries ...idcache.Entry) {
+	cache := cachedIDs()
+	if cache == nil || len(entries) == 0 {
+		return
+	}
+	for _, e := range entries {
+		e.Kind = string(kind)
+		e.HumanID = humanID(e.ID)
+		cache.Add(e)
+	}
+	idCacheChanged = true
+}
+
+// forgetID removes a document from the cache, e.g. after it was deleted
+func forgetID(kind docKind, id string) {
+	cache := cachedIDs()
+	if cache == nil {
+		return
+	}
+	cache.Remove(string(kind), id)
+	idCacheChanged = true
+}
+
+// lookupCachedID returns the cached documents matching a human or full ID.
+// When database is set, only documents in that project are returned.
+func lookupCachedID(kind docKind, database, id string, isHumanID bool) []idCandidate {
+	cache := cachedIDs()
+	if cache == nil {
+		return nil
+	}
+
+	var entries []idcache.Entry
+	if isHumanID {
+		entries = cache.LookupHumanID(string(kind), id, database)
+	} else if e, ok := cache.LookupID(string(kind), id); ok && (database == "" || e.Database == database) {
+		entries = []idcache.Entry{e}
+	}
+
+	candidates := make([]idCandidate, 0, len(entries))
+	for _, e := range entries {
+		candidates = append(candidates, idCandidate{Database: e.Database, ID: e.ID, Label: e.Label})
+	}
+	return candidates
+}
+
+// rememberTickets records the project of each ticket. database is used for tickets
+// whose project can't be derived from the response.
+func rememberTickets(database string, tickets []api.Ticket) {
+	entries := make([]idcache.Entry, 0, len(tickets))
+	for _, t := range tickets {
+		label := ""
+		if t.Content != nil {
+			label = t.Content.Title
+		}
+		entries = append(entries, idcache.Entry{
+			Database: firstNonEmpty(compoundDatabase(t.ID), t.Database, database),
+			ID:       firstNonEmpty(t.CouchDbID, t.CouchID),
+			Label:    label,
+		})
+	}
+	rememberIDs(kindTicket, entries...)
+}
+
+// rememberAudits records the project of each audit
+func rememberAudits(database string, audits []api.Audit) {
+	entries := make([]idcache.Entry, 0, len(audits))
+	for _, a := range audits {
+		entries = append(entries, idcache.Entry{
+			Database: firstNonEmpty(compoundDatabase(a.ID), a.Database, database),
+			ID:       firstNonEmpty(a.CouchDbID, a.CouchID),
+			Label:    a.Name,
+		})
+	}
+	rememberIDs(kindAudit, entries...)
+}
+
+// rememberFiles records the project of each file
+func rememberFiles(database string, files []api.File) {
+	entries := make([]idcache.Entry, 0, len(files))
+	for _, f := range files {
+		entries = append(entries, idcache.Entry{
+			Database: firstNonEmpty(compoundDatabase(f.ID), database),
+			ID:       firstNonEmpty(f.CouchDbID, f.CouchID),
+			Label:    f.Name,
+		})
+	}
+	rememberIDs(kindFile, entries...)
+}
+
+// rememberMaps records the project of each map
+func rememberMaps(database string, maps []api.Map) {
+	entries := make([]idcache.Entry, 0, len(maps))
+	for _, m := range maps {
+		entries = append(entries, idcache.Entry{
+			Database: firstNonEmpty(compoundDatabase(m.ID), database),
+			ID:       firstNonEmpty(m.CouchDbID, m.CouchID),
+			Label:    m.Name,
+		})
+	}
+	rememberIDs(kindMap, entries...)
+}
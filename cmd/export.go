@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+	"github.com/dutchview/edcontrols-cli/internal/xlsx"
+)
+
+// matrixColumns are the audit columns of an answer matrix, before the question columns
+var matrixColumns = []string{"id", "name", "status", "template", "auditor", "responsible", "created", "due", "completed", "tags"}
+
+type AuditsExportCmd struct {
+	Database      string `arg:"" name:"project-id" help:"Project ID"`
+	Template      string `short:"t" required:"" help:"Template ID"`
+	Format        string `short:"f" enum:"csv,xlsx" default:"csv" help:"Output format (csv, xlsx)"`
+	Output        string `short:"o" help:"Output file (default: audits-<template human ID>.<format>, '-' for stdout)"`
+	Status        string `short:"s" enum:"started,In Progress,completed," default:"" help:"Filter by status (started, In Progress, completed)"`
+	Archived      bool   `help:"Include archived audits"`
+	CreatedAfter  string `help:"Only audits created after this time (e.g., 2w, 3d, 1mo, 1y, or 2026-01-15)"`
+	CreatedBefore string `help:"Only audits created before this time (e.g., 2w, 3d, 1mo, 1y, or 2026-01-15)"`
+	Workers       int    `default:"8" help:"Number of audits fetched at the same time"`
+}
+
+func (c *AuditsExportCmd) Run(client *api.Client) error {
+	filters, err := ParseDateFilters(c.CreatedAfter, c.CreatedBefore, "", "")
+	if err != nil {
+		return err
+	}
+
+	templateName := c.Template
+	if t, err := client.GetAuditTemplate(c.Database, c.Template); err == nil && t.Name != "" {
+		templateName = t.Name
+	}
+
+	var ids []string
+	const pageSize = 100
+	for page := 0; ; page++ {
+		audits, _, err := client.ListAudits(api.ListAuditsOptions{
+			Database:  c.Database,
+			Template:  c.Template,
+			Status:    c.Status,
+			Archived:  c.Archived,
+			Size:      pageSize,
+			Page:      page,
+			SortBy:    "CREATIONDATE",
+			SortOrder: "ASC",
+		})
+		if err != nil {
+			return err
+		}
+		rememberAudits(c.Database, audits)
+		for _, a := range audits {
+			created := ""
+			if a.Dates != nil {
+				created = a.Dates.CreationDate
+			}
+			if filters.HasDateFilters() && !filters.MatchesDates(created, "") {
+				continue
+			}
+			ids = append(ids, a.CouchDbID)
+		}
+		if len(audits) < pageSize {
+			break
+		}
+	}
+
+	if len(ids) == 0 {
+		return fmt.Errorf("no audits found for template %s", c.Template)
+	}
+	fmt.Fprintf(os.Stderr, "Fetching %d audits...\n", len(ids))
+
+	audits, err := client.GetAudits(c.Database, ids, c.Workers)
+	if err != nil {
+		return err
+	}
+	for i, audit := range audits {
+		if audit.CouchDbID == "" {
+			audit.CouchDbID = ids[i]
+		}
+	}
+
+	rows := answerMatrix(audits, templateName)
+
+	output := c.Output
+	if output == "" {
+		output = fmt.Sprintf("audits-%s.%s", humanID(c.Template), c.Format)
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "-" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("creating export file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if c.Format == "xlsx" {
+		err = xlsx.Write(w, templateName, rows)
+	} else {
+		err = writeMatrixCSV(w, rows)
+	}
+	if err != nil {
+		return err
+	}
+
+	if output != "-" {
+		fmt.Fprintf(os.Stderr, "Exported %d audits to %s\n", len(audits), output)
+	}
+	return nil
+}
+
+// answerMatrix returns a header row and one row per audit with the audit details
+// followed by one column per question
+func answerMatrix(audits []*api.Audit, templateName string) [][]interface{} {
+	columns := api.AnswerColumns(audits)
+
+	header := make([]interface{}, 0, len(matrixColumns)+len(columns))
+	for _, name := range matrixColumns {
+		header = append(header, name)
+	}
+	for _, column := range columns {
+		header = append(header, column.Header)
+	}
+	rows := [][]interface{}{header}
+
+	for _, audit := range audits {
+		var auditor, responsible, created, due, completed string
+		if audit.Author != nil {
+			auditor = audit.Author.Email
+		}
+		if audit.Participants != nil && audit.Participants.Responsible != nil {
+			responsible = audit.Participants.Responsible.Email
+		}
+		if audit.Dates != nil {
+			created = audit.Dates.CreationDate
+			due = audit.Dates.DueDate
+			completed = audit.Dates.CompletionDate
+		}
+
+		row := []interface{}{
+			humanID(audit.CouchDbID),
+			audit.Name,
+			audit.Status,
+			firstNonEmpty(templateName, audit.TemplateName, audit.Template),
+			auditor,
+			responsible,
+			created,
+			due,
+			completed,
+			strings.Join(audit.Tags, ";"),
+		}
+		rows = append(rows, append(row, api.MatrixAnswers(audit, columns)...))
+	}
+	return rows
+}
+
+func writeMatrixCSV(w io.Writer, rows [][]interface{}) error {
+	cw := csv.NewWriter(w)
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			switch v := v.(type) {
+			case nil:
+			case float64:
+				record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+			default:
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("writing CSV: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	return nil
+}
@@ -18,7 +18,7 @@ type FilesCmd struct {
 	Archive   FilesArchiveCmd   `cmd:"" help:"Archive a file"`
 	Unarchive FilesUnarchiveCmd `cmd:"" help:"Unarchive a file"`
 	Delete    FilesDeleteCmd    `cmd:"" help:"Delete a file"`
-	Tags      FilesTagsCmd      `cmd:"" help:"Update tags on a file"`
+	Tags      FilesTagsCmd      `cmd:"" help:"Show or change tags on a file (-t set, --add, --remove, --clear)"`
 	ToMap     FilesToMapCmd     `cmd:"" help:"Convert a file to a map (tiled drawing)"`
 	Groups    FileGroupsCmd     `cmd:"" help:"Manage file groups"`
 }
@@ -148,6 +148,7 @@ func (c *FilesListCmd) Run(client *api.Client) error {
 	if err != nil {
 		return err
 	}
+	rememberFiles(c.Database, files)
 
 	if c.JSON {
 		return printJSON(files)
@@ -244,22 +245,15 @@ func (c *FilesListCmd) Run(client *api.Client) error {
 }
 
 type FilesGetCmd struct {
-	FileID   string `arg:"" help:"File ID (full CouchDB ID)"`
+	FileID   string `arg:"" help:"File ID (human ID or full CouchDB ID)"`
 	Database string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
 	JSON     bool   `short:"j" help:"Output as JSON"`
 }
 
 func (c *FilesGetCmd) Run(client *api.Client) error {
-	database := c.Database
-	fileID := c.FileID
-
-	// If no database provided, search for the file across all projects
-	if database == "" {
-		foundDB, err := findFileByID(client, fileID)
-		if err != nil {
-			return err
-		}
-		database = foundDB
+	database, fileID, err := resolveFileID(client, c.Database, c.FileID)
+	if err != nil {
+		return err
 	}
 
 	if c.JSON {
@@ -275,6 +269,7 @@ func (c *FilesGetCmd) Run(client *api.Client) error {
 	if err != nil {
 		return err
 	}
+	rememberFiles(database, []api.File{*f})
 
 	name := f.Name
 	if name == "" {
@@ -337,51 +332,6 @@ func (c *FilesGetCmd) Run(client *api.Client) error {
 	return nil
 }
 
-// findFileByID searches for a file by its full CouchDB ID across all active projects.
-// Returns the database name where the file was found.
-func findFileByID(client *api.Client, fileID string) (string, error) {
-	projects, _, err := client.ListProjects(api.ListProjectsOptions{})
-	if err != nil {
-		return "", err
-	}
-
-	var projectIDs []string
-	for _, project := range projects {
-		if project.ProjectID == "glacier_project_documents" || !project.IsActive {
-			continue
-		}
-		projectIDs = append(projectIDs, project.ProjectID)
-	}
-
-	// Try the POST search endpoint first
-	files, err := client.SearchFilesByID(projectIDs, fileID)
-	if err == nil && len(files) > 0 {
-		for _, f := range files {
-			fID := f.CouchDbID
-			if fID == "" {
-				fID = f.CouchID
-			}
-			if fID == fileID {
-				// Extract database from the file's ID field (format: database|couchDbId)
-				if f.ID != "" && strings.Contains(f.ID, "|") {
-					parts := strings.SplitN(f.ID, "|", 2)
-					return parts[0], nil
-				}
-			}
-		}
-	}
-
-	// Fallback: search each project directly
-	for _, projectID := range projectIDs {
-		_, err := client.GetFile(projectID, fileID)
-		if err == nil {
-			return projectID, nil
-		}
-	}
-
-	return "", fmt.Errorf("file with ID %s not found", fileID)
-}
-
 // getFileSize extracts the file size from an interface{} value
 func getFileSize(size interface{}) int64 {
 	if size == nil {
@@ -535,22 +485,15 @@ func getContentType(filename string) string {
 }
 
 type FilesDownloadCmd struct {
-	FileID   string `arg:"" help:"File ID (full CouchDB ID)"`
+	FileID   string `arg:"" help:"File ID (human ID or full CouchDB ID)"`
 	Database string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
 	Output   string `short:"o" help:"Output file path (defaults to original filename)"`
 }
 
 func (c *FilesDownloadCmd) Run(client *api.Client) error {
-	database := c.Database
-	fileID := c.FileID
-
-	// If no database provided, search for the file across all projects
-	if database == "" {
-		foundDB, err := findFileByID(client, fileID)
-		if err != nil {
-			return err
-		}
-		database = foundDB
+	database, fileID, err := resolveFileID(client, c.Database, c.FileID)
+	if err != nil {
+		return err
 	}
 
 	// Get file details to retrieve versionId and filename
@@ -598,54 +541,75 @@ func (c *FilesDownloadCmd) Run(client *api.Client) error {
 
 type FilesArchiveCmd struct {
 	Database string `arg:"" name:"project-id" help:"Project ID"`
-	FileID   string `arg:"" help:"File ID (full CouchDB ID)"`
+	FileID   string `arg:"" help:"File ID (human ID or full CouchDB ID)"`
 }
 
 func (c *FilesArchiveCmd) Run(client *api.Client) error {
-	if err := client.ArchiveFile(c.Database, []string{c.FileID}, true); err != nil {
+	database, fileID, err := resolveFileID(client, c.Database, c.FileID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.ArchiveFile(database, []string{fileID}, true); err != nil {
 		return fmt.Errorf("archiving file: %w", err)
 	}
 
-	fmt.Printf("File %s archived successfully.\n", c.FileID)
+	fmt.Printf("File %s archived successfully.\n", fileID)
 	return nil
 }
 
 type FilesUnarchiveCmd struct {
 	Database string `arg:"" name:"project-id" help:"Project ID"`
-	FileID   string `arg:"" help:"File ID (full CouchDB ID)"`
+	FileID   string `arg:"" help:"File ID (human ID or full CouchDB ID)"`
 }
 
 func (c *FilesUnarchiveCmd) Run(client *api.Client) error {
-	if err := client.ArchiveFile(c.Database, []string{c.FileID}, false); err != nil {
+	database, fileID, err := resolveFileID(client, c.Database, c.FileID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.ArchiveFile(database, []string{fileID}, false); err != nil {
 		return fmt.Errorf("unarchiving file: %w", err)
 	}
 
-	fmt.Printf("File %s unarchived successfully.\n", c.FileID)
+	fmt.Printf("File %s unarchived successfully.\n", fileID)
 	return nil
 }
 
 type FilesDeleteCmd struct {
 	Database string `arg:"" name:"project-id" help:"Project ID"`
-	FileID   string `arg:"" help:"File ID (full CouchDB ID)"`
+	FileID   string `arg:"" help:"File ID (human ID or full CouchDB ID)"`
 }
 
 func (c *FilesDeleteCmd) Run(client *api.Client) error {
-	if err := client.DeleteLibraryItems(c.Database, []string{c.FileID}, nil); err != nil {
+	database, fileID, err := resolveFileID(client, c.Database, c.FileID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteLibraryItems(database, []string{fileID}, nil); err != nil {
 		return fmt.Errorf("deleting file: %w", err)
 	}
+	forgetID(kindFile, fileID)
 
-	fmt.Printf("File %s deleted successfully.\n", c.FileID)
+	fmt.Printf("File %s deleted successfully.\n", fileID)
 	return nil
 }
 
 type FilesToMapCmd struct {
 	Database string `arg:"" name:"project-id" help:"Project ID"`
-	FileID   string `arg:"" help:"File ID (full CouchDB ID)"`
+	FileID   string `arg:"" help:"File ID (human ID or full CouchDB ID)"`
 }
 
 func (c *FilesToMapCmd) Run(client *api.Client) error {
+	database, fileID, err := resolveFileID(client, c.Database, c.FileID)
+	if err != nil {
+		return err
+	}
+
 	// Get file details to retrieve versionId, filename, and group
-	f, err := client.GetFile(c.Database, c.FileID)
+	f, err := client.GetFile(database, fileID)
 	if err != nil {
 		return fmt.Errorf("getting file details: %w", err)
 	}
@@ -673,7 +637,7 @@ func (c *FilesToMapCmd) Run(client *api.Client) error {
 
 	groupName := ""
 	if groupID != "" {
-		group, err := client.GetFileGroup(c.Database, groupID)
+		group, err := client.GetFileGroup(database, groupID)
 		if err == nil && group.Name != "" {
 			groupName = group.Name
 		}
@@ -681,7 +645,7 @@ func (c *FilesToMapCmd) Run(client *api.Client) error {
 
 	fmt.Printf("Converting %s to map...\n", fileName)
 
-	if err := client.ConvertFileToMap(c.Database, c.FileID, f.VersionID, fileName, groupName); err != nil {
+	if err := client.ConvertFileToMap(database, fileID, f.VersionID, fileName, groupName); err != nil {
 		return fmt.Errorf("converting file to map: %w", err)
 	}
 
@@ -700,35 +664,18 @@ func isValidMapFileType(filename string) bool {
 
 type FilesTagsCmd struct {
 	Database string   `arg:"" name:"project-id" help:"Project ID"`
-	FileID   string   `arg:"" help:"File ID (full CouchDB ID)"`
+	FileID   string   `arg:"" help:"File ID (human ID or full CouchDB ID)"`
 	Tags     []string `short:"t" help:"Tags to set (replaces existing tags)"`
+	Add      []string `help:"Tags to add, keeping existing tags (can be specified multiple times)"`
+	Remove   []string `help:"Tags to remove, keeping other tags (can be specified multiple times)"`
 	Clear    bool     `help:"Clear all tags from the file"`
 }
 
 func (c *FilesTagsCmd) Run(client *api.Client) error {
-	// If no tags specified and not clearing, list current tags
-	if len(c.Tags) == 0 && !c.Clear {
-		f, err := client.GetFile(c.Database, c.FileID)
-		if err != nil {
-			return fmt.Errorf("getting file: %w", err)
-		}
-		if len(f.Tags) == 0 {
-			fmt.Println("No tags.")
-		} else {
-			fmt.Printf("Tags: %v\n", f.Tags)
-		}
-		return nil
-	}
-
-	// Clear or update tags
-	if err := client.UpdateDocumentTags(c.Database, c.FileID, c.Tags); err != nil {
-		return fmt.Errorf("updating tags: %w", err)
+	database, fileID, err := resolveFileID(client, c.Database, c.FileID)
+	if err != nil {
+		return err
 	}
 
-	if c.Clear {
-		fmt.Printf("Tags cleared from file %s.\n", c.FileID)
-	} else {
-		fmt.Printf("Tags updated on file %s: %v\n", c.FileID, c.Tags)
-	}
-	return nil
+	return runTagEdit(client, database, fileID, "file", fileID, newTagEdit(c.Tags, c.Clear, c.Add, c.Remove))
 }
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+	"github.com/dutchview/edcontrols-cli/internal/idcache"
+)
+
+type AuditsFindingsCmd struct {
+	AuditID         string   `arg:"" help:"Audit ID (human ID like '708739' or full CouchDB ID)"`
+	Database        string   `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	RatingThreshold float64  `default:"3" help:"Ratings below this value are findings (0 to leave ratings out)"`
+	CreateTickets   bool     `help:"Create a ticket for each finding that has no linked ticket"`
+	Responsible     string   `short:"r" help:"Assign the new tickets to this email"`
+	Due             string   `help:"Due date of the new tickets (e.g., 3d, 2w, 1mo from now, 2026-03-15, or ISO 8601)"`
+	Tags            []string `name:"tag" help:"Tags for the new tickets (can be specified multiple times)"`
+	NoPhotos        bool     `help:"Don't copy the question photos to the tickets"`
+	DryRun          bool     `help:"Show which tickets would be created without creating them"`
+	JSON            bool     `short:"j" help:"Output as JSON"`
+}
+
+// findingResult is a finding with the outcome of --create-tickets
+type findingResult struct {
+	api.Finding
+	Result   string `json:"result,omitempty"` // created, linked, would create or error
+	TicketID string `json:"ticketId,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (c *AuditsFindingsCmd) Run(client *api.Client) error {
+	due := ""
+	if c.Due != "" {
+		var err error
+		due, err = ParseDueDate(c.Due, time.Now())
+		if err != nil {
+			return fmt.Errorf("--due: %w", err)
+		}
+	}
+
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
+	}
+
+	doc, err := client.GetDocument(database, auditID)
+	if err != nil {
+		return fmt.Errorf("getting audit: %w", err)
+	}
+	auditName, _ := doc["name"].(string)
+
+	findings := api.FindAuditFindings(doc, c.RatingThreshold)
+	results := make([]findingResult, len(findings))
+	for i, f := range findings {
+		results[i] = findingResult{Finding: f}
+	}
+
+	failed := 0
+	if c.CreateTickets {
+		for i := range results {
+			r := &results[i]
+			switch {
+			case r.Linked():
+				r.Result = "linked"
+			case c.DryRun:
+				r.Result = "would create"
+			default:
+				r.TicketID, err = c.createFindingTicket(client, database, auditID, auditName, due, r.Finding)
+				if err != nil {
+					r.Result = "error"
+					r.Error = err.Error()
+					failed++
+				} else {
+					r.Result = "created"
+				}
+			}
+		}
+	}
+
+	if c.JSON {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		fmt.Printf("No findings in audit %s.\n", humanID(auditID))
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "CATEGORY\t#\tQUESTION\tANSWER\tTICKET")
+		fmt.Fprintln(w, "--------\t-\t--------\t------\t------")
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", truncate(r.Category, 25), r.Number, truncate(r.Question, 45), truncate(r.Answer, 20), findingTicketColumn(r))
+		}
+		w.Flush()
+		fmt.Printf("\nTotal: %d findings\n", len(results))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tickets could not be created or linked", failed, len(results))
+	}
+	return nil
+}
+
+// findingTicketColumn describes the linked or created ticket of a finding
+func findingTicketColumn(r findingResult) string {
+	var ids []string
+	for _, id := range r.Tickets {
+		ids = append(ids, humanID(id))
+	}
+	linked := strings.Join(ids, ", ")
+
+	switch r.Result {
+	case "created":
+		return "created " + humanID(r.TicketID)
+	case "error":
+		if r.TicketID != "" {
+			return fmt.Sprintf("error (ticket %s): %s", humanID(r.TicketID), r.Error)
+		}
+		return "error: " + r.Error
+	case "would create":
+		return "would create"
+	}
+	if linked == "" {
+		if r.TicketRequired {
+			return "- (required)"
+		}
+		return "-"
+	}
+	return linked
+}
+
+// createFindingTicket creates a ticket for a finding, with the question photos, and
+// links it to the question. due is the parsed --due date. Returns the ticket ID, also
+// when only linking failed.
+func (c *AuditsFindingsCmd) createFindingTicket(client *api.Client, database, auditID, auditName, due string, f api.Finding) (string, error) {
+	opts := api.CreateTicketOptions{
+		Database:    database,
+		Title:       f.Question,
+		Description: sanitizeHTML(findingDescription(auditName, humanID(auditID), f)),
+		Responsible: c.Responsible,
+		DueDate:     due,
+		Tags:        c.Tags,
+	}
+
+	if !c.NoPhotos {
+		for _, name := range f.Photos {
+			data, err := client.DownloadAttachment(database, auditID, name)
+			if err != nil {
+				return "", fmt.Errorf("downloading photo %s: %w", name, err)
+			}
+			opts.Photos = append(opts.Photos, api.AttachmentFile{
+				Name:        path.Base(name),
+				ContentType: getContentType(name),
+				Data:        data,
+			})
+		}
+	}
+
+	ticketID, err := client.CreateTicket(opts)
+	if err != nil {
+		return "", fmt.Errorf("creating ticket: %w", err)
+	}
+	rememberIDs(kindTicket, idcache.Entry{Database: database, ID: ticketID, Label: f.Question})
+
+	if err := client.LinkFindingTicket(database, auditID, f, ticketID); err != nil {
+		return ticketID, fmt.Errorf("linking ticket: %w", err)
+	}
+	return ticketID, nil
+}
+
+// findingDescription builds the HTML description of a finding ticket
+func findingDescription(auditName, auditHumanID string, f api.Finding) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<p><strong>Audit:</strong> %s (%s)</p>\n", html.EscapeString(auditName), auditHumanID)
+	fmt.Fprintf(&b, "<p><strong>Question:</strong> %s / %d. %s</p>\n", html.EscapeString(f.Category), f.Number, html.EscapeString(f.Question))
+	if f.Description != "" {
+		fmt.Fprintf(&b, "<p>%s</p>\n", html.EscapeString(f.Description))
+	}
+	fmt.Fprintf(&b, "<p><strong>Answer:</strong> %s</p>\n", html.EscapeString(f.Answer))
+	if len(f.Comments) > 0 {
+		b.WriteString("<p><strong>Comments:</strong></p>\n<ul>\n")
+		for _, comment := range f.Comments {
+			fmt.Fprintf(&b, "<li>%s</li>\n", html.EscapeString(comment))
+		}
+		b.WriteString("</ul>\n")
+	}
+	return b.String()
+}
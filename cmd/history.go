@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+// HistoryFlags are the output and filter flags shared by the history commands
+type HistoryFlags struct {
+	Author   string `short:"a" help:"Only show changes by this author (substring match on email)"`
+	Property string `help:"Only show changes to this property (e.g. 'state', 'plan.dueDate')"`
+	Platform string `help:"Only show changes made from this platform userInterface (e.g. 'cli', 'web', 'ios')"`
+	Format   string `short:"f" default:"table" enum:"table,json,diff" help:"Output format: table, json, or diff"`
+}
+
+type TicketsHistoryCmd struct {
+	TicketID     string `arg:"" help:"Ticket ID (human ID like 'CC455B' or full CouchDB ID)"`
+	Database     string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	HistoryFlags `embed:""`
+}
+
+func (c *TicketsHistoryCmd) Run(client *api.Client) error {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	return showHistory(client, database, ticketID, c.HistoryFlags)
+}
+
+type AuditsHistoryCmd struct {
+	AuditID      string `arg:"" help:"Audit ID (human ID like '708739' or full CouchDB ID)"`
+	Database     string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	HistoryFlags `embed:""`
+}
+
+func (c *AuditsHistoryCmd) Run(client *api.Client) error {
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
+	}
+
+	return showHistory(client, database, auditID, c.HistoryFlags)
+}
+
+// showHistory fetches, filters and renders the operation log of a document
+func showHistory(client *api.Client, database, docID string, flags HistoryFlags) error {
+	ops, err := client.GetOperations(database, docID)
+	if err != nil {
+		return fmt.Errorf("getting history: %w", err)
+	}
+
+	ops = filterOperations(ops, flags.Author, flags.Property, flags.Platform)
+
+	switch flags.Format {
+	case "json":
+		return printJSON(ops)
+	case "diff":
+		printHistoryDiff(ops)
+	default:
+		printHistoryTable(ops)
+	}
+
+	if flags.Format != "json" {
+		fmt.Printf("\nTotal: %d changes\n", len(ops))
+	}
+	return nil
+}
+
+// filterOperations keeps the operations matching all non-empty filters.
+// When filtering by property, only the matching properties of each operation are kept.
+func filterOperations(ops []api.Operation, author, property, platform string) []api.Operation {
+	var result []api.Operation
+	for _, op := range ops {
+		if author != "" && !strings.Contains(strings.ToLower(op.Author), strings.ToLower(author)) {
+			continue
+		}
+		if platform != "" && (op.Platform == nil || !strings.EqualFold(op.Platform.UserInterface, platform)) {
+			continue
+		}
+		if property != "" {
+			op = onlyProperty(op, property)
+			if len(op.ChangedProperties) == 0 {
+				continue
+			}
+		}
+		result = append(result, op)
+	}
+	return result
+}
+
+// onlyProperty narrows an operation down to a single property.
+// A property also matches its nested properties, so 'plan' matches 'plan.dueDate'.
+func onlyProperty(op api.Operation, property string) api.Operation {
+	narrowed := op
+	narrowed.ChangedProperties = nil
+	narrowed.OldValues = nil
+	narrowed.NewValues = nil
+
+	for i, p := range op.ChangedProperties {
+		if p != property && !strings.HasPrefix(p, property+".") {
+			continue
+		}
+		narrowed.ChangedProperties = append(narrowed.ChangedProperties, p)
+		narrowed.OldValues = append(narrowed.OldValues, valueAt(op.OldValues, i))
+		narrowed.NewValues = append(narrowed.NewValues, valueAt(op.NewValues, i))
+	}
+	return narrowed
+}
+
+func valueAt(values []interface{}, i int) interface{} {
+	if i < len(values) {
+		return values[i]
+	}
+	return nil
+}
+
+func printHistoryTable(ops []api.Operation) {
+	if len(ops) == 0 {
+		fmt.Println("No changes found.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tAUTHOR\tACTION\tPLATFORM\tPROPERTY\tOLD\tNEW")
+	fmt.Fprintln(w, "----\t------\t------\t--------\t--------\t---\t---")
+
+	for _, op := range ops {
+		when := formatOperationTime(op.Time)
+		author := truncate(statusString(op.Author), 30)
+		action := statusString(op.ActionType)
+		platform := statusString(operationPlatform(op))
+
+		if len(op.ChangedProperties) == 0 {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", when, author, action, platform, "-", "-", "-")
+			continue
+		}
+		for i, prop := range op.ChangedProperties {
+			oldVal := truncate(formatHistoryValue(valueAt(op.OldValues, i)), 30)
+			newVal := truncate(formatHistoryValue(valueAt(op.NewValues, i)), 30)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", when, author, action, platform, prop, oldVal, newVal)
+			// Only print the change header once per operation
+			when, author, action, platform = "", "", "", ""
+		}
+	}
+
+	w.Flush()
+}
+
+func printHistoryDiff(ops []api.Operation) {
+	if len(ops) == 0 {
+		fmt.Println("No changes found.")
+		return
+	}
+
+	for i, op := range ops {
+		if i > 0 {
+			fmt.Println()
+		}
+		header := fmt.Sprintf("@@ %s  %s", formatOperationTime(op.Time), statusString(op.Author))
+		if op.ActionType != "" {
+			header += "  " + op.ActionType
+		}
+		if p := operationPlatform(op); p != "" {
+			header += "  (" + p + ")"
+		}
+		fmt.Println(header + " @@")
+		if op.Summary != "" {
+			fmt.Printf("# %s\n", op.Summary)
+		}
+
+		for j, prop := range op.ChangedProperties {
+			fmt.Printf("--- %s\n+++ %s\n", prop, prop)
+			for _, line := range diffLines(valueAt(op.OldValues, j)) {
+				fmt.Printf("-%s\n", line)
+			}
+			for _, line := range diffLines(valueAt(op.NewValues, j)) {
+				fmt.Printf("+%s\n", line)
+			}
+		}
+	}
+}
+
+// diffLines renders a value as lines for the diff output. Missing values produce no lines.
+func diffLines(v interface{}) []string {
+	if v == nil {
+		return nil
+	}
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err == nil {
+			return strings.Split(string(data), "\n")
+		}
+	}
+	return strings.Split(formatHistoryValue(v), "\n")
+}
+
+// formatHistoryValue renders an old/new value from the operation log on a single line
+func formatHistoryValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		if val == "" {
+			return `""`
+		}
+		return val
+	case map[string]interface{}:
+		// Person objects are shown by email
+		if email, ok := val["email"].(string); ok && email != "" {
+			return email
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// formatOperationTime shortens an ISO timestamp to "YYYY-MM-DD HH:MM:SS"
+func formatOperationTime(t string) string {
+	if len(t) >= 19 {
+		return t[:10] + " " + t[11:19]
+	}
+	return statusString(t)
+}
+
+func operationPlatform(op api.Operation) string {
+	if op.Platform == nil {
+		return ""
+	}
+	return op.Platform.UserInterface
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+func TestFilterOperations(t *testing.T) {
+	ops := []api.Operation{
+		{
+			Author:            "john@example.com",
+			Time:              "2026-03-01T10:00:00.000Z",
+			ChangedProperties: []string{"content.title", "plan.dueDate"},
+			OldValues:         []interface{}{"Old", nil},
+			NewValues:         []interface{}{"New", "2026-03-10T00:00:00.000Z"},
+			Platform:          &api.OperationPlatform{UserInterface: "cli"},
+		},
+		{
+			Author:            "jane@example.com",
+			Time:              "2026-03-02T10:00:00.000Z",
+			ChangedProperties: []string{"state"},
+			OldValues:         []interface{}{"created"},
+			NewValues:         []interface{}{"completed"},
+			Platform:          &api.OperationPlatform{UserInterface: "web"},
+		},
+		{
+			Author:            "john@example.com",
+			Time:              "2026-03-03T10:00:00.000Z",
+			ChangedProperties: []string{"state"},
+			OldValues:         []interface{}{"completed"},
+			NewValues:         []interface{}{"created"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		author   string
+		property string
+		platform string
+		want     []string // times of the expected operations
+	}{
+		{name: "no filters", want: []string{"2026-03-01T10:00:00.000Z", "2026-03-02T10:00:00.000Z", "2026-03-03T10:00:00.000Z"}},
+		{name: "author substring", author: "JOHN", want: []string{"2026-03-01T10:00:00.000Z", "2026-03-03T10:00:00.000Z"}},
+		{name: "property", property: "state", want: []string{"2026-03-02T10:00:00.000Z", "2026-03-03T10:00:00.000Z"}},
+		{name: "property prefix", property: "plan", want: []string{"2026-03-01T10:00:00.000Z"}},
+		{name: "platform", platform: "web", want: []string{"2026-03-02T10:00:00.000Z"}},
+		{name: "combined", author: "john", property: "state", want: []string{"2026-03-03T10:00:00.000Z"}},
+		{name: "no match", platform: "ios", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, op := range filterOperations(ops, tt.author, tt.property, tt.platform) {
+				got = append(got, op.Time)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterOperations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterOperationsNarrowsProperties(t *testing.T) {
+	ops := []api.Operation{{
+		ChangedProperties: []string{"content.title", "plan.dueDate"},
+		OldValues:         []interface{}{"Old", nil},
+		NewValues:         []interface{}{"New", "2026-03-10T00:00:00.000Z"},
+	}}
+
+	got := filterOperations(ops, "", "plan.dueDate", "")
+	if len(got) != 1 {
+		t.Fatalf("got %d operations, want 1", len(got))
+	}
+	if !reflect.DeepEqual(got[0].ChangedProperties, []string{"plan.dueDate"}) {
+		t.Errorf("ChangedProperties = %v", got[0].ChangedProperties)
+	}
+	if !reflect.DeepEqual(got[0].NewValues, []interface{}{"2026-03-10T00:00:00.000Z"}) {
+		t.Errorf("NewValues = %v", got[0].NewValues)
+	}
+	// The original operation must not be modified
+	if len(ops[0].ChangedProperties) != 2 {
+		t.Errorf("original operation was modified: %v", ops[0].ChangedProperties)
+	}
+}
@@ -0,0 +1,358 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+type AuditsImportCmd struct {
+	File     string `arg:"" type:"existingfile" help:"Answer-matrix CSV file (as written by audits export)"`
+	Database string `short:"p" name:"project" required:"" help:"Project ID"`
+	DryRun   bool   `help:"Validate the file and show what would be imported without saving"`
+	JSON     bool   `short:"j" help:"Output as JSON"`
+}
+
+// importRow is a data row of an answer-matrix file
+type importRow struct {
+	Line        int
+	ID          string
+	Name        string
+	Template    string
+	Responsible string
+	Due         string // Parsed by checkFields
+	Tags        []string
+	Values      []string // Answers, in the order of the question columns
+}
+
+// checkFields validates the responsible and due columns of a row and replaces the
+// due date with the date as stored in documents
+func (row *importRow) checkFields(now time.Time) error {
+	if row.Responsible != "" {
+		if _, err := mail.ParseAddress(row.Responsible); err != nil {
+			return fmt.Errorf("responsible %q is not an email address", row.Responsible)
+		}
+	}
+	if row.Due != "" {
+		due, err := ParseDueDate(row.Due, now)
+		if err != nil {
+			return fmt.Errorf("due: %w", err)
+		}
+		row.Due = due
+	}
+	return nil
+}
+
+// auditFields returns the changes the name, responsible, due and tags columns of a
+// row make to an existing audit. Empty columns leave the audit unchanged.
+func (row importRow) auditFields() api.UpdateAuditFieldsOptions {
+	var opts api.UpdateAuditFieldsOptions
+	if row.Name != "" {
+		opts.Name = &row.Name
+	}
+	if row.Responsible != "" {
+		opts.Responsible = &row.Responsible
+	}
+	if row.Due != "" {
+		opts.DueDate = &row.Due
+	}
+	if len(row.Tags) > 0 {
+		opts.Tags = row.Tags
+	}
+	return opts
+}
+
+// importResult is the outcome of importing one row
+type importResult struct {
+	Row      int      `json:"row"`
+	Audit    string   `json:"audit"`
+	Name     string   `json:"name"`
+	Action   string   `json:"action"` // create, update or skip
+	Answers  int      `json:"answers"`
+	Fields   []string `json:"fields,omitempty"` // Audit fields an update changes
+	Template string   `json:"template,omitempty"`
+
+	row      importRow
+	auditID  string
+	template *api.AuditTemplate
+}
+
+func (c *AuditsImportCmd) Run(client *api.Client) error {
+	f, err := os.Open(c.File)
+	if err != nil {
+		return fmt.Errorf("opening import file: %w", err)
+	}
+	defer f.Close()
+
+	columns, rows, err := parseImportCSV(f)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("import file has no rows")
+	}
+
+	// Validate every row before writing anything
+	var templates []api.AuditTemplate
+	templateDocs := make(map[string]map[string]interface{})
+	var results []*importResult
+	var errs []string
+	for _, row := range rows {
+		r, err := c.checkRow(client, row, columns, &templates, templateDocs)
+		if err != nil {
+			ref := row.ID
+			if ref == "" {
+				ref = "new"
+			}
+			errs = append(errs, fmt.Sprintf("row %d (%s): %v", row.Line, ref, err))
+			continue
+		}
+		results = append(results, r)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("nothing imported, fix these rows first:\n%s", strings.Join(errs, "\n"))
+	}
+
+	if !c.DryRun {
+		for _, r := range results {
+			if err := c.importRow(client, r, columns); err != nil {
+				return fmt.Errorf("row %d: %w (earlier rows were imported)", r.Row, err)
+			}
+		}
+	}
+
+	if c.JSON {
+		return printJSON(results)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROW\tAUDIT\tACTION\tANSWERS\tFIELDS\tNAME")
+	fmt.Fprintln(w, "---\t-----\t------\t-------\t------\t----")
+	for _, r := range results {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%s\n", r.Row, textOrDash(r.Audit), r.Action, r.Answers, textOrDash(strings.Join(r.Fields, ", ")), truncate(r.Name, 50))
+	}
+	w.Flush()
+
+	if c.DryRun {
+		fmt.Println("\nDry run, nothing was saved.")
+	}
+	return nil
+}
+
+// checkRow validates the audit columns and the answers of a row against its audit,
+// or against the template for rows that create a new audit
+func (c *AuditsImportCmd) checkRow(client *api.Client, row importRow, columns []string, templates *[]api.AuditTemplate, templateDocs map[string]map[string]interface{}) (*importResult, error) {
+	if err := row.checkFields(time.Now()); err != nil {
+		return nil, err
+	}
+	r := &importResult{Row: row.Line, Name: row.Name, row: row}
+
+	var doc map[string]interface{}
+	if row.ID != "" {
+		_, auditID, err := resolveAuditID(client, c.Database, row.ID)
+		if err != nil {
+			return nil, err
+		}
+		doc, err = client.GetDocument(c.Database, auditID)
+		if err != nil {
+			return nil, fmt.Errorf("getting audit: %w", err)
+		}
+		r.Audit = humanID(auditID)
+		r.auditID = auditID
+		r.Action = "update"
+		if r.Name == "" {
+			r.Name, _ = doc["name"].(string)
+		}
+	} else {
+		if row.Template == "" {
+			return nil, fmt.Errorf("id or template is required")
+		}
+		if *templates == nil {
+			list, _, err := client.ListAuditTemplates(api.ListAuditTemplatesOptions{Database: c.Database, Size: 500})
+			if err != nil {
+				return nil, fmt.Errorf("listing templates: %w", err)
+			}
+			*templates = list
+		}
+		template, err := findTemplateByRef(*templates, row.Template)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := templateDocs[template.CouchDbID]; !ok {
+			templateDoc, err := client.GetDocument(c.Database, template.CouchDbID)
+			if err != nil {
+				return nil, fmt.Errorf("getting template: %w", err)
+			}
+			templateDocs[template.CouchDbID] = templateDoc
+		}
+		// Answers are checked on a copy so the cached template stays clean
+		doc = copyDocument(templateDocs[template.CouchDbID])
+		r.template = template
+		r.Template = template.Name
+		r.Action = "create"
+		if r.Name == "" {
+			r.Name = template.Name
+		}
+	}
+
+	answers, err := api.ApplyMatrixAnswers(doc, columns, row.Values)
+	if err != nil {
+		return nil, err
+	}
+	r.Answers = len(answers)
+	if r.Action == "update" {
+		r.Fields, err = api.ApplyAuditFields(doc, row.auditFields())
+		if err != nil {
+			return nil, err
+		}
+		if r.Answers == 0 && len(r.Fields) == 0 {
+			r.Action = "skip"
+		}
+	}
+	return r, nil
+}
+
+// importRow creates the audit of a row if needed, or updates its audit fields, and
+// saves its answers
+func (c *AuditsImportCmd) importRow(client *api.Client, r *importResult, columns []string) error {
+	row := r.row
+	if r.Action == "skip" {
+		return nil
+	}
+
+	if r.Action == "create" {
+		audit, err := client.CreateAudit(c.Database, r.template.CouchDbID, api.CreateAuditOptions{
+			Name:        row.Name,
+			Responsible: row.Responsible,
+			DueDate:     row.Due,
+			Tags:        row.Tags,
+		})
+		if err != nil {
+			return fmt.Errorf("creating audit: %w", err)
+		}
+		rememberAudits(c.Database, []api.Audit{*audit})
+		r.auditID = audit.CouchDbID
+		r.Audit = humanID(audit.CouchDbID)
+		if audit.Name != "" {
+			r.Name = audit.Name
+		}
+	} else if len(r.Fields) > 0 {
+		if _, err := client.UpdateAuditFields(c.Database, r.auditID, row.auditFields()); err != nil {
+			return fmt.Errorf("updating audit: %w", err)
+		}
+	}
+	if r.Answers == 0 {
+		return nil
+	}
+
+	_, err := client.ImportAuditAnswers(c.Database, r.auditID, columns, row.Values)
+	return err
+}
+
+// parseImportCSV reads an answer-matrix CSV. It returns the question column
+// headers and the data rows; the audit columns (see matrixColumns) are recognised
+// by name and the columns that are only informational (status, dates...) ignored.
+func parseImportCSV(r io.Reader) ([]string, []importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing import file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("import file is empty")
+	}
+
+	fixed := make(map[string]bool, len(matrixColumns))
+	for _, name := range matrixColumns {
+		fixed[name] = true
+	}
+
+	auditColumns := make(map[string]int)
+	var questionColumns []string
+	var questionIndexes []int
+	for i, name := range records[0] {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if key := strings.ToLower(name); fixed[key] {
+			auditColumns[key] = i
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		questionColumns = append(questionColumns, name)
+		questionIndexes = append(questionIndexes, i)
+	}
+	if _, ok := auditColumns["id"]; !ok {
+		if _, ok := auditColumns["template"]; !ok {
+			return nil, nil, fmt.Errorf("import file needs an id or template column")
+		}
+	}
+	if len(questionColumns) == 0 {
+		return nil, nil, fmt.Errorf("import file has no question columns")
+	}
+
+	field := func(record []string, i int) string {
+		if i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	column := func(record []string, name string) string {
+		if i, ok := auditColumns[name]; ok {
+			return field(record, i)
+		}
+		return ""
+	}
+
+	var rows []importRow
+	for n, record := range records[1:] {
+		empty := true
+		for _, v := range record {
+			if strings.TrimSpace(v) != "" {
+				empty = false
+				break
+			}
+		}
+		if empty {
+			continue
+		}
+
+		row := importRow{
+			Line:        n + 2,
+			ID:          column(record, "id"),
+			Name:        column(record, "name"),
+			Template:    column(record, "template"),
+			Responsible: column(record, "responsible"),
+			Due:         column(record, "due"),
+			Values:      make([]string, len(questionIndexes)),
+		}
+		for _, tag := range strings.Split(column(record, "tags"), ";") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				row.Tags = append(row.Tags, tag)
+			}
+		}
+		for i, index := range questionIndexes {
+			row.Values[i] = field(record, index)
+		}
+		rows = append(rows, row)
+	}
+	return questionColumns, rows, nil
+}
+
+// copyDocument returns a deep copy of a JSON document
+func copyDocument(doc map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(doc)
+	var copied map[string]interface{}
+	_ = json.Unmarshal(data, &copied)
+	return copied
+}
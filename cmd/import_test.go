@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	data := "\ufeffid,name,status,template,responsible,due,tags,Fire safety / Exits free?,Housekeeping / Issues\n" +
+		"CC455B,Walk 1,completed,Safety walk,,,,Yes,\n" +
+		",,,,,,,,\n" +
+		",Walk 2,,Safety walk,jane@example.com,2026-12-31T23:59:59Z,site;weekly,No,Dust;Clutter\n"
+
+	columns, rows, err := parseImportCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("parseImportCSV: %v", err)
+	}
+	if want := []string{"Fire safety / Exits free?", "Housekeeping / Issues"}; !reflect.DeepEqual(columns, want) {
+		t.Errorf("columns = %v, want %v", columns, want)
+	}
+
+	want := []importRow{
+		{Line: 2, ID: "CC455B", Name: "Walk 1", Template: "Safety walk", Values: []string{"Yes", ""}},
+		{Line: 4, Name: "Walk 2", Template: "Safety walk", Responsible: "jane@example.com", Due: "2026-12-31T23:59:59Z",
+			Tags: []string{"site", "weekly"}, Values: []string{"No", "Dust;Clutter"}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %+v, want %+v", rows, want)
+	}
+}
+
+func TestParseImportCSVErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"empty", "", "empty"},
+		{"no id or template", "name,Fire safety / Exits free?\nWalk,Yes\n", "id or template column"},
+		{"no questions", "id,name\nCC455B,Walk\n", "no question columns"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseImportCSV(strings.NewReader(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportRowCheckFields(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	row := importRow{Responsible: "jane@example.com", Due: "2026-12-31"}
+	if err := row.checkFields(now); err != nil {
+		t.Fatalf("checkFields: %v", err)
+	}
+	if row.Due != "2026-12-31T23:59:59.000Z" {
+		t.Errorf("Due = %q, want 2026-12-31T23:59:59.000Z", row.Due)
+	}
+
+	tests := []struct {
+		name string
+		row  importRow
+		want string
+	}{
+		{"bad due", importRow{Due: "end of year"}, "due: "},
+		{"bad responsible", importRow{Responsible: "jane"}, "not an email address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.row.checkFields(now)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportRowAuditFields(t *testing.T) {
+	if opts := (importRow{ID: "CC455B"}).auditFields(); !opts.IsEmpty() {
+		t.Errorf("empty columns should change nothing, got %+v", opts)
+	}
+
+	row := importRow{Name: "Walk 1", Responsible: "jane@example.com", Due: "2026-12-31T23:59:59.000Z", Tags: []string{"site"}}
+	opts := row.auditFields()
+	if opts.Name == nil || *opts.Name != row.Name || opts.Responsible == nil || *opts.Responsible != row.Responsible ||
+		opts.DueDate == nil || *opts.DueDate != row.Due || !reflect.DeepEqual(opts.Tags, row.Tags) {
+		t.Errorf("auditFields() = %+v", opts)
+	}
+}
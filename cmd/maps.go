@@ -15,7 +15,7 @@ type MapsCmd struct {
 	Get    MapsGetCmd    `cmd:"" help:"Get map details"`
 	Add    MapsAddCmd    `cmd:"" help:"Add a new map (upload and convert PDF/image)"`
 	Delete MapsDeleteCmd `cmd:"" help:"Delete a map"`
-	Tags   MapsTagsCmd   `cmd:"" help:"Update tags on a map"`
+	Tags   MapsTagsCmd   `cmd:"" help:"Show or change tags on a map (-t set, --add, --remove, --clear)"`
 	Groups MapGroupsCmd  `cmd:"" help:"Manage map groups"`
 }
 
@@ -121,6 +121,7 @@ func (c *MapsListCmd) Run(client *api.Client) error {
 	if err != nil {
 		return err
 	}
+	rememberMaps(c.Database, maps)
 
 	if c.JSON {
 		return printJSON(maps)
@@ -203,22 +204,15 @@ func (c *MapsListCmd) Run(client *api.Client) error {
 }
 
 type MapsGetCmd struct {
-	MapID    string `arg:"" help:"Map ID (full CouchDB ID)"`
+	MapID    string `arg:"" help:"Map ID (human ID or full CouchDB ID)"`
 	Database string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
 	JSON     bool   `short:"j" help:"Output as JSON"`
 }
 
 func (c *MapsGetCmd) Run(client *api.Client) error {
-	database := c.Database
-	mapID := c.MapID
-
-	// If no database provided, search for the map across all projects
-	if database == "" {
-		foundDB, err := findMapByID(client, mapID)
-		if err != nil {
-			return err
-		}
-		database = foundDB
+	database, mapID, err := resolveMapID(client, c.Database, c.MapID)
+	if err != nil {
+		return err
 	}
 
 	if c.JSON {
@@ -234,6 +228,7 @@ func (c *MapsGetCmd) Run(client *api.Client) error {
 	if err != nil {
 		return err
 	}
+	rememberMaps(database, []api.Map{*m})
 
 	fmt.Printf("Map: %s\n", m.Name)
 	fmt.Printf("ID: %s\n", mapID)
@@ -270,51 +265,6 @@ func (c *MapsGetCmd) Run(client *api.Client) error {
 	return nil
 }
 
-// findMapByID searches for a map by its full CouchDB ID across all active projects.
-// Returns the database name where the map was found.
-func findMapByID(client *api.Client, mapID string) (string, error) {
-	projects, _, err := client.ListProjects(api.ListProjectsOptions{})
-	if err != nil {
-		return "", err
-	}
-
-	var projectIDs []string
-	for _, project := range projects {
-		if project.ProjectID == "glacier_project_documents" || !project.IsActive {
-			continue
-		}
-		projectIDs = append(projectIDs, project.ProjectID)
-	}
-
-	// Try the POST search endpoint first
-	maps, err := client.SearchMapsByID(projectIDs, mapID)
-	if err == nil && len(maps) > 0 {
-		for _, m := range maps {
-			mID := m.CouchDbID
-			if mID == "" {
-				mID = m.CouchID
-			}
-			if mID == mapID {
-				// Extract database from the map's ID field (format: database|couchDbId)
-				if m.ID != "" && strings.Contains(m.ID, "|") {
-					parts := strings.SplitN(m.ID, "|", 2)
-					return parts[0], nil
-				}
-			}
-		}
-	}
-
-	// Fallback: search each project directly
-	for _, projectID := range projectIDs {
-		_, err := client.GetMap(projectID, mapID)
-		if err == nil {
-			return projectID, nil
-		}
-	}
-
-	return "", fmt.Errorf("map with ID %s not found", mapID)
-}
-
 type MapsAddCmd struct {
 	Database    string   `arg:"" name:"project-id" help:"Project ID"`
 	FileGroupID string   `arg:"" help:"File group ID (where the file will be stored)"`
@@ -473,49 +423,38 @@ func (c *MapsAddCmd) Run(client *api.Client) error {
 
 type MapsDeleteCmd struct {
 	Database string `arg:"" name:"project-id" help:"Project ID"`
-	MapID    string `arg:"" help:"Map ID (full CouchDB ID)"`
+	MapID    string `arg:"" help:"Map ID (human ID or full CouchDB ID)"`
 }
 
 func (c *MapsDeleteCmd) Run(client *api.Client) error {
-	if err := client.DeleteLibraryItems(c.Database, nil, []string{c.MapID}); err != nil {
+	database, mapID, err := resolveMapID(client, c.Database, c.MapID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteLibraryItems(database, nil, []string{mapID}); err != nil {
 		return fmt.Errorf("deleting map: %w", err)
 	}
+	forgetID(kindMap, mapID)
 
-	fmt.Printf("Map %s deleted successfully.\n", c.MapID)
+	fmt.Printf("Map %s deleted successfully.\n", mapID)
 	return nil
 }
 
 type MapsTagsCmd struct {
 	Database string   `arg:"" name:"project-id" help:"Project ID"`
-	MapID    string   `arg:"" help:"Map ID (full CouchDB ID)"`
+	MapID    string   `arg:"" help:"Map ID (human ID or full CouchDB ID)"`
 	Tags     []string `short:"t" help:"Tags to set (replaces existing tags)"`
+	Add      []string `help:"Tags to add, keeping existing tags (can be specified multiple times)"`
+	Remove   []string `help:"Tags to remove, keeping other tags (can be specified multiple times)"`
 	Clear    bool     `help:"Clear all tags from the map"`
 }
 
 func (c *MapsTagsCmd) Run(client *api.Client) error {
-	// If no tags specified and not clearing, list current tags
-	if len(c.Tags) == 0 && !c.Clear {
-		m, err := client.GetMap(c.Database, c.MapID)
-		if err != nil {
-			return fmt.Errorf("getting map: %w", err)
-		}
-		if len(m.Tags) == 0 {
-			fmt.Println("No tags.")
-		} else {
-			fmt.Printf("Tags: %v\n", m.Tags)
-		}
-		return nil
-	}
-
-	// Clear or update tags
-	if err := client.UpdateDocumentTags(c.Database, c.MapID, c.Tags); err != nil {
-		return fmt.Errorf("updating tags: %w", err)
+	database, mapID, err := resolveMapID(client, c.Database, c.MapID)
+	if err != nil {
+		return err
 	}
 
-	if c.Clear {
-		fmt.Printf("Tags cleared from map %s.\n", c.MapID)
-	} else {
-		fmt.Printf("Tags updated on map %s: %v\n", c.MapID, c.Tags)
-	}
-	return nil
+	return runTagEdit(client, database, mapID, "map", mapID, newTagEdit(c.Tags, c.Clear, c.Add, c.Remove))
 }
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+// ParticipantFlags are the flags shared by the participants commands
+type ParticipantFlags struct {
+	Inform  []string `short:"i" help:"Email to add to the informed participants (can be specified multiple times)"`
+	Consult []string `help:"Email to add to the consulted participants (can be specified multiple times)"`
+	Remove  []string `help:"Email to remove from the informed and consulted participants (can be specified multiple times)"`
+	JSON    bool     `short:"j" help:"Output as JSON"`
+}
+
+type TicketsParticipantsCmd struct {
+	TicketID         string `arg:"" help:"Ticket ID (human ID like 'CC455B' or full CouchDB ID)"`
+	Database         string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	ParticipantFlags `embed:""`
+}
+
+func (c *TicketsParticipantsCmd) Run(client *api.Client) error {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	return runParticipantsEdit(client, database, ticketID, c.ParticipantFlags)
+}
+
+type AuditsParticipantsCmd struct {
+	AuditID          string `arg:"" help:"Audit ID (human ID like '708739' or full CouchDB ID)"`
+	Database         string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	ParticipantFlags `embed:""`
+}
+
+func (c *AuditsParticipantsCmd) Run(client *api.Client) error {
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
+	}
+
+	return runParticipantsEdit(client, database, auditID, c.ParticipantFlags)
+}
+
+// runParticipantsEdit shows the participants of a document, after applying
+// the requested changes if any were given
+func runParticipantsEdit(client *api.Client, database, docID string, flags ParticipantFlags) error {
+	edit := api.ParticipantsEdit{
+		Inform:  flags.Inform,
+		Consult: flags.Consult,
+		Remove:  flags.Remove,
+	}
+
+	var participants *api.Participants
+	var err error
+	if edit.IsEmpty() {
+		participants, err = client.GetParticipants(database, docID)
+		if err != nil {
+			return fmt.Errorf("getting participants: %w", err)
+		}
+	} else {
+		participants, err = client.UpdateParticipants(database, docID, edit)
+		if err != nil {
+			return fmt.Errorf("updating participants: %w", err)
+		}
+	}
+
+	if flags.JSON {
+		return printJSON(participants)
+	}
+
+	responsible := "-"
+	if participants.Responsible != nil && participants.Responsible.Email != "" {
+		responsible = participants.Responsible.Email
+	}
+	fmt.Printf("Responsible: %s\n", responsible)
+	fmt.Printf("Informed: %s\n", personEmailList(participants.Informed))
+	fmt.Printf("Consulted: %s\n", personEmailList(participants.Consulted))
+	return nil
+}
+
+// personEmailList formats a list of persons as a comma-separated list of emails
+func personEmailList(persons []api.Person) string {
+	if len(persons) == 0 {
+		return "-"
+	}
+	emails := make([]string, 0, len(persons))
+	for _, p := range persons {
+		emails = append(emails, p.Email)
+	}
+	return strings.Join(emails, ", ")
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+	"github.com/dutchview/edcontrols-cli/internal/report"
+)
+
+type AuditsReportCmd struct {
+	AuditID  string `arg:"" help:"Audit ID (human ID like '708739' or full CouchDB ID)"`
+	Database string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	Format   string `short:"f" enum:"pdf,html" default:"pdf" help:"Report format (pdf, html)"`
+	Output   string `short:"o" help:"Output file (default: audit-<human ID>.<format>, '-' for stdout)"`
+	Logo     string `type:"existingfile" help:"Logo image (PNG or JPEG) for the report header"`
+	NoPhotos bool   `help:"Leave out attachment photos"`
+}
+
+func (c *AuditsReportCmd) Run(client *api.Client) error {
+	database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+	if err != nil {
+		return err
+	}
+
+	audit, err := client.GetAudit(database, auditID)
+	if err != nil {
+		return fmt.Errorf("getting audit: %w", err)
+	}
+
+	r := report.New(audit, humanID(auditID))
+
+	r.Project = database
+	if project, err := client.GetProject(database); err == nil && project.ProjectName != "" {
+		r.Project = project.ProjectName
+	}
+	if audit.Template != "" {
+		if template, err := client.GetAuditTemplate(database, audit.Template); err == nil && template.Name != "" {
+			r.Template = template.Name
+		}
+	}
+
+	if c.Logo != "" {
+		logo, err := loadReportImage(c.Logo)
+		if err != nil {
+			return err
+		}
+		r.Logo = &logo
+	}
+
+	if !c.NoPhotos {
+		photos, err := reportPhotos(client, database, auditID, c.Format)
+		if err != nil {
+			return err
+		}
+		r.Photos = photos
+	}
+
+	output := c.Output
+	if output == "" {
+		output = fmt.Sprintf("audit-%s.%s", humanID(auditID), c.Format)
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "-" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("creating report file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch c.Format {
+	case "html":
+		err = report.WriteHTML(w, r)
+	default:
+		err = report.WritePDF(w, r)
+	}
+	if err != nil {
+		return err
+	}
+
+	if output != "-" {
+		fmt.Fprintf(os.Stderr, "Report written to %s\n", output)
+	}
+	return nil
+}
+
+// reportPhotos downloads the image attachments of an audit (without thumbnails).
+// PDF reports only support JPEG, PNG and GIF; other images are skipped with a warning.
+func reportPhotos(client *api.Client, database, auditID, format string) ([]report.Image, error) {
+	doc, err := client.GetDocument(database, auditID)
+	if err != nil {
+		return nil, fmt.Errorf("getting audit: %w", err)
+	}
+
+	var photos []report.Image
+	for _, a := range extractAttachments(doc) {
+		if a.Thumbnail || !strings.HasPrefix(a.ContentType, "image/") {
+			continue
+		}
+		if format == "pdf" && !pdfImageType(a.ContentType) {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s (%s is not supported in PDF reports)\n", a.Name, a.ContentType)
+			continue
+		}
+
+		data, err := client.DownloadAttachment(database, auditID, a.Name)
+		if err != nil {
+			return nil, fmt.Errorf("downloading %s: %w", a.Name, err)
+		}
+		photos = append(photos, report.Image{Name: a.Name, ContentType: a.ContentType, Data: data})
+	}
+	return photos, nil
+}
+
+// loadReportImage reads an image file, e.g. a logo
+func loadReportImage(path string) (report.Image, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report.Image{}, fmt.Errorf("reading image: %w", err)
+	}
+	contentType := http.DetectContentType(data)
+	if !pdfImageType(contentType) {
+		return report.Image{}, fmt.Errorf("%s is not a PNG, JPEG or GIF image", path)
+	}
+	return report.Image{Name: path, ContentType: contentType, Data: data}, nil
+}
+
+func pdfImageType(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/jpg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+	"github.com/dutchview/edcontrols-cli/internal/idcache"
+)
+
+// docKind identifies the type of document an ID refers to
+type docKind string
+
+const (
+	kindTicket docKind = "ticket"
+	kindAudit  docKind = "audit"
+	kindFile   docKind = "file"
+	kindMap    docKind = "map"
+)
+
+// idCandidate is a document that matches a user-supplied ID
+type idCandidate struct {
+	Database string
+	ID       string
+	Label    string // Title or name, to help the user pick the right one
+}
+
+func resolveTicketID(client *api.Client, database, id string) (string, string, error) {
+	return resolveID(client, kindTicket, database, id)
+}
+
+func resolveAuditID(client *api.Client, database, id string) (string, string, error) {
+	return resolveID(client, kindAudit, database, id)
+}
+
+func resolveFileID(client *api.Client, database, id string) (string, string, error) {
+	return resolveID(client, kindFile, database, id)
+}
+
+func resolveMapID(client *api.Client, database, id string) (string, string, error) {
+	return resolveID(client, kindMap, database, id)
+}
+
+// resolveID turns a user-supplied document ID into a project ID and full CouchDB ID.
+// Accepted forms:
+//   - compound IDs "database|couchId", as returned by the search API
+//   - human IDs (the last 6 characters reversed, e.g. CC455B)
+//   - full CouchDB IDs
+//
+// When database is set, the search is limited to that project. If a human ID matches
+// more than one document, the user is asked to pick one (or gets an error listing the
+// candidates when not running in a terminal).
+//
+// When the local ID cache holds a single match, the document is fetched from the
+// cached project to confirm it is still there and used without searching. A human ID
+// shared with a document in another project the cache hasn't seen is then not noticed.
+func resolveID(client *api.Client, kind docKind, database, id string) (string, string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", "", fmt.Errorf("%s ID is empty", kind)
+	}
+
+	if db, couchID, ok := splitCompoundID(id); ok {
+		if database != "" && database != db {
+			return "", "", fmt.Errorf("%s %s belongs to project %s, not %s", kind, couchID, db, database)
+		}
+		return db, couchID, nil
+	}
+
+	isHumanID := len(id) <= 6
+
+	// A full ID in a known project needs no lookup
+	if !isHumanID && database != "" {
+		return database, id, nil
+	}
+
+	if cached := lookupCachedID(kind, database, id, isHumanID); len(cached) == 1 {
+		if documentExists(client, kind, cached[0].Database, cached[0].ID) {
+			return cached[0].Database, cached[0].ID, nil
+		}
+		forgetID(kind, cached[0].ID)
+	}
+
+	projectIDs, err := searchProjects(client, database)
+	if err != nil {
+		return "", "", err
+	}
+
+	candidates, err := searchCandidates(client, kind, projectIDs, id, isHumanID)
+	if err != nil {
+		return "", "", err
+	}
+	rememberCandidates(kind, candidates)
+
+	switch len(candidates) {
+	case 0:
+		return "", "", fmt.Errorf("%s with ID %s not found", kind, id)
+	case 1:
+		return candidates[0].Database, candidates[0].ID, nil
+	default:
+		c, err := chooseCandidate(kind, id, candidates)
+		if err != nil {
+			return "", "", err
+		}
+		return c.Database, c.ID, nil
+	}
+}
+
+// rememberCandidates stores search results in the ID cache
+func rememberCandidates(kind docKind, candidates []idCandidate) {
+	entries := make([]idcache.Entry, 0, len(candidates))
+	for _, c := range candidates {
+		entries = append(entries, idcache.Entry{Database: c.Database, ID: c.ID, Label: c.Label})
+	}
+	rememberIDs(kind, entries...)
+}
+
+// splitCompoundID splits a "database|couchId" ID
+func splitCompoundID(id string) (string, string, bool) {
+	parts := strings.SplitN(id, "|", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// searchProjects returns the projects to search: the given one, or all active projects
+func searchProjects(client *api.Client, database string) ([]string, error) {
+	if database != "" {
+		return []string{database}, nil
+	}
+
+	projects, _, err := client.ListProjects(api.ListProjectsOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var projectIDs []string
+	for _, project := range projects {
+		// Skip glacier projects and inactive projects for faster search
+		if project.ProjectID == "glacier_project_documents" || !project.IsActive {
+			continue
+		}
+		projectIDs = append(projectIDs, project.ProjectID)
+	}
+	return projectIDs, nil
+}
+
+// searchCandidates finds the documents whose ID (or human ID) matches id.
+// The search API matches IDs ending with the value or its reverse (case-insensitive),
+// so the results are verified before they are returned.
+func searchCandidates(client *api.Client, kind docKind, projectIDs []string, id string, isHumanID bool) ([]idCandidate, error) {
+	searchID := id
+	if isHumanID {
+		searchID = strings.ToLower(id)
+	}
+
+	var found []idCandidate
+	var searchErr error
+	switch kind {
+	case kindTicket:
+		tickets, err := client.SearchTicketsByID(projectIDs, searchID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tickets {
+			label := ""
+			if t.Content != nil {
+				label = t.Content.Title
+			}
+			found = append(found, idCandidate{Database: compoundDatabase(t.ID), ID: firstNonEmpty(t.CouchDbID, t.CouchID), Label: label})
+		}
+	case kindAudit:
+		audits, err := client.SearchAuditsByID(projectIDs, searchID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range audits {
+			found = append(found, idCandidate{Database: compoundDatabase(a.ID), ID: firstNonEmpty(a.CouchDbID, a.CouchID), Label: a.Name})
+		}
+	case kindFile:
+		// A failed search is only reported when the direct lookup below doesn't find the file
+		var files []api.File
+		files, searchErr = client.SearchFilesByID(projectIDs, searchID)
+		for _, f := range files {
+			found = append(found, idCandidate{Database: compoundDatabase(f.ID), ID: firstNonEmpty(f.CouchDbID, f.CouchID), Label: f.Name})
+		}
+	case kindMap:
+		var maps []api.Map
+		maps, searchErr = client.SearchMapsByID(projectIDs, searchID)
+		for _, m := range maps {
+			found = append(found, idCandidate{Database: compoundDatabase(m.ID), ID: firstNonEmpty(m.CouchDbID, m.CouchID), Label: m.Name})
+		}
+	}
+
+	candidates := filterCandidates(found, id, isHumanID, func(docID string) string {
+		return locateDocument(client, kind, projectIDs, docID)
+	})
+
+	// Full IDs that the search endpoint doesn't know about can still be found directly
+	if len(candidates) == 0 && !isHumanID {
+		if db := locateDocument(client, kind, projectIDs, id); db != "" {
+			candidates = append(candidates, idCandidate{Database: db, ID: id})
+		}
+	}
+
+	if len(candidates) == 0 && searchErr != nil {
+		return nil, fmt.Errorf("searching %ss: %w", kind, searchErr)
+	}
+	return candidates, nil
+}
+
+// filterCandidates keeps the search results whose ID really matches id, without
+// duplicates. locate finds the project of results that came back without one;
+// results it can't find are dropped.
+func filterCandidates(found []idCandidate, id string, isHumanID bool, locate func(docID string) string) []idCandidate {
+	var candidates []idCandidate
+	seen := make(map[string]bool)
+	for _, c := range found {
+		if isHumanID && humanID(c.ID) != strings.ToUpper(id) {
+			continue
+		}
+		if !isHumanID && c.ID != id {
+			continue
+		}
+		if c.Database == "" {
+			c.Database = locate(c.ID)
+			if c.Database == "" {
+				continue
+			}
+		}
+		key := c.Database + "|" + c.ID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		candidates = append(candidates, c)
+	}
+	return candidates
+}
+
+// locateDocument finds the project a document lives in by fetching it from each project
+func locateDocument(client *api.Client, kind docKind, projectIDs []string, id string) string {
+	for _, projectID := range projectIDs {
+		if documentExists(client, kind, projectID, id) {
+			return projectID
+		}
+	}
+	return ""
+}
+
+// documentExists reports whether a document can be fetched from a project
+func documentExists(client *api.Client, kind docKind, database, id string) bool {
+	var err error
+	switch kind {
+	case kindTicket:
+		_, err = client.GetTicket(database, id)
+	case kindAudit:
+		_, err = client.GetAudit(database, id)
+	case kindFile:
+		_, err = client.GetFile(database, id)
+	case kindMap:
+		_, err = client.GetMap(database, id)
+	}
+	return err == nil
+}
+
+// compoundDatabase returns the database part of a "database|couchId" ID
+func compoundDatabase(id string) string {
+	if db, _, ok := splitCompoundID(id); ok {
+		return db
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// chooseCandidate asks the user to pick one of several documents sharing a human ID.
+// When stdin is not a terminal, an error listing the candidates is returned instead.
+func chooseCandidate(kind docKind, id string, candidates []idCandidate) (idCandidate, error) {
+	var list strings.Builder
+	for i, c := range candidates {
+		label := c.Label
+		if label == "" {
+			label = "-"
+		}
+		fmt.Fprintf(&list, "  %d) %s|%s  %s\n", i+1, c.Database, c.ID, truncate(label, 50))
+	}
+
+	if !stdinIsTerminal() {
+		return idCandidate{}, fmt.Errorf("%d %ss match ID %s, use the full ID or -p to pick one:\n%s",
+			len(candidates), kind, strings.ToUpper(id), strings.TrimRight(list.String(), "\n"))
+	}
+
+	fmt.Fprintf(os.Stderr, "Multiple %ss match ID %s:\n%s", kind, strings.ToUpper(id), list.String())
+	fmt.Fprintf(os.Stderr, "Choose [1-%d]: ", len(candidates))
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	n, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || n < 1 || n > len(candidates) {
+		return idCandidate{}, fmt.Errorf("no %s selected", kind)
+	}
+	return candidates[n-1], nil
+}
+
+func stdinIsTerminal() bool {
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
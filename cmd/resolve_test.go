@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitCompoundID(t *testing.T) {
+	tests := []struct {
+		input  string
+		wantDB string
+		wantID string
+		wantOK bool
+	}{
+		{"nl_company_abc123|d67c1aba0b017a1c9372e726c6512a1f", "nl_company_abc123", "d67c1aba0b017a1c9372e726c6512a1f", true},
+		{"d67c1aba0b017a1c9372e726c6512a1f", "", "", false},
+		{"F1A215", "", "", false},
+		{"|d67c1aba", "", "", false},
+		{"nl_company_abc123|", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			db, id, ok := splitCompoundID(tt.input)
+			if db != tt.wantDB || id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("splitCompoundID(%q) = (%q, %q, %v), want (%q, %q, %v)", tt.input, db, id, ok, tt.wantDB, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFilterCandidates(t *testing.T) {
+	const (
+		full  = "d67c1aba0b017a1c9372e726c6512a1f"
+		other = "0b017a1c9372e726c6512a1fd67c1aba" // human ID ABA1C7
+	)
+	located := func(docID string) string {
+		if docID == full {
+			return "located_project"
+		}
+		return ""
+	}
+
+	tests := []struct {
+		name      string
+		found     []idCandidate
+		id        string
+		isHumanID bool
+		want      []idCandidate
+	}{
+		{
+			name:      "human ID must be the reversed suffix",
+			found:     []idCandidate{{Database: "p1", ID: full}, {Database: "p1", ID: other}},
+			id:        "f1a215",
+			isHumanID: true,
+			want:      []idCandidate{{Database: "p1", ID: full}},
+		},
+		{
+			name:  "full ID must match exactly",
+			found: []idCandidate{{Database: "p1", ID: full}, {Database: "p1", ID: "x" + full}},
+			id:    full,
+			want:  []idCandidate{{Database: "p1", ID: full}},
+		},
+		{
+			name:      "duplicates are dropped",
+			found:     []idCandidate{{Database: "p1", ID: full, Label: "a"}, {Database: "p1", ID: full, Label: "b"}, {Database: "p2", ID: full}},
+			id:        "F1A215",
+			isHumanID: true,
+			want:      []idCandidate{{Database: "p1", ID: full, Label: "a"}, {Database: "p2", ID: full}},
+		},
+		{
+			name:  "results without a project are located",
+			found: []idCandidate{{ID: full}},
+			id:    full,
+			want:  []idCandidate{{Database: "located_project", ID: full}},
+		},
+		{
+			name:      "results that can't be located are dropped",
+			found:     []idCandidate{{ID: other}},
+			id:        "ABA1C7",
+			isHumanID: true,
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterCandidates(tt.found, tt.id, tt.isHumanID, located)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterCandidates() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChooseCandidateNotTerminal(t *testing.T) {
+	// A pipe is not a terminal, so the candidates are listed in the error
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	candidates := []idCandidate{
+		{Database: "p1", ID: "d67c1aba0b017a1c9372e726c6512a1f", Label: "Crack in wall"},
+		{Database: "p2", ID: "0000000000000000000000000c6512a1f"},
+	}
+	_, err = chooseCandidate(kindTicket, "f1a215", candidates)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"2 tickets match ID F1A215", "1) p1|d67c1aba0b017a1c9372e726c6512a1f  Crack in wall", "2) p2|0000000000000000000000000c6512a1f  -"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q doesn't contain %q", err, want)
+		}
+	}
+}
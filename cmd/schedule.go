@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+	"github.com/dutchview/edcontrols-cli/internal/schedule"
+)
+
+type AuditsScheduleCmd struct {
+	RunSchedule AuditsScheduleRunCmd `cmd:"" name:"run" help:"Create the audits that are due according to a schedule file"`
+}
+
+type AuditsScheduleRunCmd struct {
+	File   string `short:"f" required:"" type:"existingfile" help:"Schedule file (YAML)"`
+	Date   string `help:"Run the schedule as if it were this date (YYYY-MM-DD, default today)"`
+	DryRun bool   `help:"Show which audits would be created without creating them"`
+	JSON   bool   `short:"j" help:"Output as JSON"`
+}
+
+// scheduleResult is the outcome of one schedule rule in one project
+type scheduleResult struct {
+	Schedule string `json:"schedule"`
+	Project  string `json:"project"`
+	Period   string `json:"period"`
+	Name     string `json:"name,omitempty"`
+	Result   string `json:"result"` // created, exists, would create or error
+	AuditID  string `json:"auditId,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (c *AuditsScheduleRunCmd) Run(client *api.Client) error {
+	rules, err := schedule.Load(c.File)
+	if err != nil {
+		return err
+	}
+
+	day := time.Now()
+	if c.Date != "" {
+		day, err = time.ParseInLocation("2006-01-02", c.Date, time.Local)
+		if err != nil {
+			return fmt.Errorf("--date: invalid date %q (use YYYY-MM-DD)", c.Date)
+		}
+	}
+
+	templates := make(map[string][]api.AuditTemplate)
+	var results []scheduleResult
+	failed := 0
+	for _, rule := range rules {
+		occurrence := rule.Occurrence(day)
+		for _, project := range rule.Projects {
+			r := runScheduleRule(client, templates, rule, project, occurrence, c.DryRun)
+			if r.Error != "" {
+				failed++
+			}
+			results = append(results, r)
+		}
+	}
+
+	if c.JSON {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SCHEDULE\tPROJECT\tPERIOD\tNAME\tRESULT")
+		fmt.Fprintln(w, "--------\t-------\t------\t----\t------")
+		for _, r := range results {
+			result := r.Result
+			switch {
+			case r.Error != "":
+				result = "error: " + r.Error
+			case r.AuditID != "":
+				result += " (" + humanID(r.AuditID) + ")"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", truncate(r.Schedule, 25), r.Project, r.Period, truncate(r.Name, 40), result)
+		}
+		w.Flush()
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d scheduled audits failed", failed, len(results))
+	}
+	return nil
+}
+
+// runScheduleRule creates the audit of a rule in one project, unless an audit with
+// the same name and template already exists. templates caches the templates per project.
+func runScheduleRule(client *api.Client, templates map[string][]api.AuditTemplate, rule schedule.Rule, project string, occurrence schedule.Occurrence, dryRun bool) scheduleResult {
+	r := scheduleResult{
+		Schedule: firstNonEmpty(rule.Name, rule.Template),
+		Project:  project,
+		Period:   occurrence.Period,
+	}
+	fail := func(err error) scheduleResult {
+		r.Result = "error"
+		r.Error = err.Error()
+		return r
+	}
+
+	if _, ok := templates[project]; !ok {
+		list, _, err := client.ListAuditTemplates(api.ListAuditTemplatesOptions{Database: project, Size: 500})
+		if err != nil {
+			return fail(fmt.Errorf("listing templates: %w", err))
+		}
+		templates[project] = list
+	}
+	template, err := findTemplateByRef(templates[project], rule.Template)
+	if err != nil {
+		return fail(err)
+	}
+
+	r.Name = rule.AuditName(template.Name, occurrence)
+	due, err := rule.DueDate(occurrence)
+	if err != nil {
+		return fail(err)
+	}
+
+	existing, _, err := client.ListAudits(api.ListAuditsOptions{
+		Database:    project,
+		Template:    template.CouchDbID,
+		SearchTitle: r.Name,
+		Archived:    true,
+		Size:        50,
+	})
+	if err != nil {
+		return fail(fmt.Errorf("checking existing audits: %w", err))
+	}
+	for _, a := range existing {
+		if a.Name == r.Name {
+			r.Result = "exists"
+			r.AuditID = a.CouchDbID
+			return r
+		}
+	}
+
+	if dryRun {
+		r.Result = "would create"
+		return r
+	}
+
+	audit, err := client.CreateAudit(project, template.CouchDbID, api.CreateAuditOptions{
+		Name:        r.Name,
+		Responsible: rule.Responsible,
+		DueDate:     due,
+		Tags:        rule.Tags,
+	})
+	if err != nil {
+		return fail(fmt.Errorf("creating audit: %w", err))
+	}
+	rememberAudits(project, []api.Audit{*audit})
+
+	r.Result = "created"
+	r.AuditID = firstNonEmpty(audit.CouchDbID, audit.ID)
+	return r
+}
+
+// findTemplateByRef finds a template by ID or (case-insensitive) name, so a
+// schedule rule or import file can refer to the same template in several projects
+func findTemplateByRef(templates []api.AuditTemplate, ref string) (*api.AuditTemplate, error) {
+	var matches []*api.AuditTemplate
+	for i := range templates {
+		t := &templates[i]
+		if t.CouchDbID == ref {
+			return t, nil
+		}
+		if strings.EqualFold(t.Name, ref) {
+			matches = append(matches, t)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("template %q not found", ref)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, fmt.Errorf("template name %q is ambiguous (%d templates), use the template ID", ref, len(matches))
+}
@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+	"github.com/dutchview/edcontrols-cli/internal/report"
+)
+
+type AuditsStatsCmd struct {
+	AuditID       string `arg:"" optional:"" help:"Audit ID (omit to compute statistics over a filtered set of audits)"`
+	Database      string `short:"p" name:"project" help:"Project ID (omit to use all active projects)"`
+	Status        string `short:"s" enum:"started,In Progress,completed," default:"" help:"Filter by status (started, In Progress, completed)"`
+	Template      string `short:"t" help:"Filter by template ID"`
+	Search        string `help:"Search by title"`
+	Auditor       string `short:"a" help:"Filter by auditor email"`
+	GroupID       string `short:"g" help:"Filter by group ID"`
+	Tag           string `help:"Filter by tag"`
+	Archived      bool   `help:"Include archived audits"`
+	AllProjects   bool   `help:"Include inactive projects when searching all"`
+	CreatedAfter  string `help:"Only audits created after this time (e.g., 2w, 3d, 1mo, 1y, or 2026-01-15)"`
+	CreatedBefore string `help:"Only audits created before this time (e.g., 2w, 3d, 1mo, 1y, or 2026-01-15)"`
+	Limit         int    `short:"l" default:"200" help:"Maximum number of audits to include"`
+	Period        string `default:"week" enum:"week,month" help:"Trend period (week, month)"`
+	Format        string `short:"f" default:"table" enum:"table,json,csv" help:"Output format: table, json, or csv"`
+}
+
+func (c *AuditsStatsCmd) Run(client *api.Client) error {
+	stats := api.NewAuditStats(c.Period)
+
+	if c.AuditID != "" {
+		database, auditID, err := resolveAuditID(client, c.Database, c.AuditID)
+		if err != nil {
+			return err
+		}
+		audit, err := client.GetAudit(database, auditID)
+		if err != nil {
+			return fmt.Errorf("getting audit: %w", err)
+		}
+		template := audit.TemplateName
+		if audit.Template != "" {
+			if t, err := client.GetAuditTemplate(database, audit.Template); err == nil && t.Name != "" {
+				template = t.Name
+			}
+		}
+		stats.Add(audit, database, firstNonEmpty(template, audit.Template), auditDate(audit))
+	} else if err := c.collect(client, stats); err != nil {
+		return err
+	}
+
+	switch c.Format {
+	case "json":
+		return printJSON(stats)
+	case "csv":
+		return writeStatsCSV(stats)
+	}
+
+	if len(stats.Audits) == 0 {
+		fmt.Println("No audits found.")
+		return nil
+	}
+	printStats(stats, c.AuditID == "")
+	return nil
+}
+
+// collect adds the audits matching the filters to stats. The list endpoint
+// doesn't return answers, so every audit is fetched separately.
+func (c *AuditsStatsCmd) collect(client *api.Client, stats *api.AuditStats) error {
+	filters, err := ParseDateFilters(c.CreatedAfter, c.CreatedBefore, "", "")
+	if err != nil {
+		return err
+	}
+
+	var databases []string
+	if c.Database != "" {
+		databases = []string{c.Database}
+	} else {
+		projects, _, err := client.ListProjects(api.ListProjectsOptions{})
+		if err != nil {
+			return err
+		}
+		for _, project := range projects {
+			// Skip glacier projects
+			if project.ProjectID == "glacier_project_documents" {
+				continue
+			}
+			// Skip inactive projects unless --all-projects is set
+			if !project.IsActive && !c.AllProjects {
+				continue
+			}
+			databases = append(databases, project.ProjectID)
+		}
+	}
+
+	count := 0
+	for _, database := range databases {
+		templateNames := make(map[string]string)
+		templates, _, err := client.ListAuditTemplates(api.ListAuditTemplatesOptions{
+			Database: database,
+			Size:     500,
+		})
+		if err == nil {
+			for _, t := range templates {
+				templateNames[t.CouchDbID] = t.Name
+			}
+		}
+
+		const pageSize = 100
+		for page := 0; count < c.Limit; page++ {
+			audits, _, err := client.ListAudits(api.ListAuditsOptions{
+				Database:    database,
+				Status:      c.Status,
+				Template:    c.Template,
+				SearchTitle: c.Search,
+				Auditor:     c.Auditor,
+				GroupID:     c.GroupID,
+				Tag:         c.Tag,
+				Archived:    c.Archived,
+				Size:        pageSize,
+				Page:        page,
+				SortBy:      "CREATIONDATE",
+				SortOrder:   "DESC",
+			})
+			if err != nil {
+				if c.Database != "" {
+					return err
+				}
+				break // Skip projects with errors
+			}
+			rememberAudits(database, audits)
+
+			for _, a := range audits {
+				if count >= c.Limit {
+					break
+				}
+				created := ""
+				if a.Dates != nil {
+					created = a.Dates.CreationDate
+				}
+				if filters.HasDateFilters() && !filters.MatchesDates(created, "") {
+					continue
+				}
+
+				audit, err := client.GetAudit(database, a.CouchDbID)
+				if err != nil {
+					return fmt.Errorf("getting audit %s: %w", humanID(a.CouchDbID), err)
+				}
+				template := firstNonEmpty(templateNames[audit.Template], audit.TemplateName, audit.Template)
+				stats.Add(audit, database, template, auditDate(audit))
+				count++
+			}
+			if len(audits) < pageSize {
+				break
+			}
+		}
+		if count >= c.Limit {
+			fmt.Fprintf(os.Stderr, "Limit of %d audits reached. Use -l to include more.\n", c.Limit)
+			break
+		}
+	}
+	return nil
+}
+
+// auditDate returns the completion date of an audit, or its creation date if it isn't completed
+func auditDate(audit *api.Audit) time.Time {
+	if audit.Dates == nil {
+		return time.Time{}
+	}
+	for _, s := range []string{audit.Dates.CompletionDate, audit.Dates.CreationDate} {
+		if t, err := parseAPIDate(s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func printStats(stats *api.AuditStats, showTrends bool) {
+	if len(stats.Audits) == 1 {
+		a := stats.Audits[0]
+		fmt.Printf("Audit %s: %s\n", humanID(a.ID), a.Name)
+	} else {
+		fmt.Printf("Audits: %d\n", len(stats.Audits))
+	}
+	fmt.Printf("Compliance: %s (%d yes, %d no, %d n.a., %d unanswered)\n\n",
+		report.ComplianceText(stats.Total), stats.Total.Yes, stats.Total.No, stats.Total.NA, stats.Total.Unanswered)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CATEGORY\tQUESTIONS\tANSWERED\tUNANSWERED\tYES\tNO\tN.A.\tCOMPLIANCE")
+	fmt.Fprintln(w, "--------\t---------\t--------\t----------\t---\t--\t----\t----------")
+	scores := append([]api.CategoryScore{}, stats.Categories...)
+	for _, s := range append(scores, stats.Total) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n", truncate(s.Category, 40), s.Questions, s.Answered, s.Unanswered, s.Yes, s.No, s.NA, report.ComplianceText(s))
+	}
+	w.Flush()
+
+	if len(stats.Averages) > 0 {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "CATEGORY\tQUESTION\tTYPE\tANSWERS\tAVERAGE\tMIN\tMAX")
+		fmt.Fprintln(w, "--------\t--------\t----\t-------\t-------\t---\t---")
+		for _, a := range stats.Averages {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\n", truncate(a.Category, 25), truncate(a.Question, 40), a.AnswerType, a.Count,
+				formatNumber(a.Average), formatNumber(a.Min), formatNumber(a.Max))
+		}
+		w.Flush()
+	}
+
+	if showTrends && len(stats.Trends) > 0 {
+		fmt.Printf("\nTrend per %s:\n", stats.Period)
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PROJECT\tTEMPLATE\tPERIOD\tAUDITS\tYES\tNO\tN.A.\tCOMPLIANCE")
+		fmt.Fprintln(w, "-------\t--------\t------\t------\t---\t--\t----\t----------")
+		for _, t := range stats.Trends {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\n", truncate(t.Project, 25), truncate(t.Template, 30), t.Period, t.Audits,
+				t.Score.Yes, t.Score.No, t.Score.NA, report.ComplianceText(t.Score))
+		}
+		w.Flush()
+	}
+}
+
+// writeStatsCSV writes all statistics as one CSV table; the "type" column tells
+// the rows apart (audit, category, total, average, trend)
+func writeStatsCSV(stats *api.AuditStats) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"type", "project", "template", "period", "audit", "category", "question",
+		"audits", "questions", "answered", "unanswered", "yes", "no", "na", "compliance", "count", "average", "min", "max"})
+
+	score := func(s api.CategoryScore) []string {
+		pct := ""
+		if v, ok := s.Compliance(); ok {
+			pct = strconv.FormatFloat(v, 'f', 1, 64)
+		}
+		return []string{strconv.Itoa(s.Questions), strconv.Itoa(s.Answered), strconv.Itoa(s.Unanswered),
+			strconv.Itoa(s.Yes), strconv.Itoa(s.No), strconv.Itoa(s.NA), pct, "", "", "", ""}
+	}
+
+	for _, a := range stats.Audits {
+		w.Write(append([]string{"audit", a.Project, a.Template, a.Date, humanID(a.ID), "", a.Name, "1"}, score(a.Score)...))
+	}
+	for _, s := range stats.Categories {
+		w.Write(append([]string{"category", "", "", "", "", s.Category, "", strconv.Itoa(len(stats.Audits))}, score(s)...))
+	}
+	w.Write(append([]string{"total", "", "", "", "", "", "", strconv.Itoa(len(stats.Audits))}, score(stats.Total)...))
+	for _, a := range stats.Averages {
+		w.Write([]string{"average", "", "", "", "", a.Category, a.Question, "", "", "", "", "", "", "", "",
+			strconv.Itoa(a.Count), formatNumber(a.Average), formatNumber(a.Min), formatNumber(a.Max)})
+	}
+	for _, t := range stats.Trends {
+		w.Write(append([]string{"trend", t.Project, t.Template, t.Period, "", "", "", strconv.Itoa(t.Audits)}, score(t.Score)...))
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	return nil
+}
+
+// formatNumber formats a number with at most two decimals
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+// TagEditFlags are the tag flags shared by the tags commands
+type TagEditFlags struct {
+	Add    []string `help:"Tags to add (can be specified multiple times)"`
+	Remove []string `help:"Tags to remove (can be specified multiple times)"`
+	Set    []string `help:"Replace all tags with these (can be specified multiple times)"`
+	Clear  bool     `help:"Remove all tags"`
+}
+
+func (f TagEditFlags) edit() api.TagEdit {
+	return newTagEdit(f.Set, f.Clear, f.Add, f.Remove)
+}
+
+// newTagEdit builds a tag edit from command flags. An empty set list means "keep existing tags".
+func newTagEdit(set []string, clear bool, add, remove []string) api.TagEdit {
+	edit := api.TagEdit{Clear: clear, Add: add, Remove: remove}
+	if len(set) > 0 {
+		edit.Set = set
+	}
+	return edit
+}
+
+// runTagEdit lists the tags of a document when the edit is empty, otherwise applies
+// the edit (read-modify-write) and prints the resulting tags. kind is used in messages,
+// e.g. "ticket", and label identifies the document to the user.
+func runTagEdit(client *api.Client, database, docID, kind, label string, edit api.TagEdit) error {
+	if edit.Clear && edit.Set != nil {
+		return fmt.Errorf("--clear and setting tags cannot be combined")
+	}
+
+	if edit.IsEmpty() {
+		tags, err := client.GetDocumentTags(database, docID)
+		if err != nil {
+			return fmt.Errorf("getting %s: %w", kind, err)
+		}
+		if len(tags) == 0 {
+			fmt.Println("No tags.")
+		} else {
+			fmt.Printf("Tags: %s\n", strings.Join(tags, ", "))
+		}
+		return nil
+	}
+
+	tags, err := client.EditDocumentTags(database, docID, edit)
+	if err != nil {
+		return fmt.Errorf("updating tags: %w", err)
+	}
+
+	if len(tags) == 0 {
+		fmt.Printf("Tags cleared from %s %s.\n", kind, label)
+	} else {
+		fmt.Printf("Tags on %s %s: %s\n", kind, label, strings.Join(tags, ", "))
+	}
+	return nil
+}
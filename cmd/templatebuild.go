@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+type TemplatesBuildCmd struct {
+	File   string `arg:"" type:"existingfile" help:"YAML questions file"`
+	Output string `short:"o" help:"Write the questions JSON to this file instead of stdout"`
+}
+
+func (c *TemplatesBuildCmd) Run() error {
+	categories, err := api.LoadTemplateBuildFile(c.File)
+	if err != nil {
+		return err
+	}
+
+	if c.Output == "" {
+		return printJSON(categories)
+	}
+
+	data, err := json.MarshalIndent(categories, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding JSON: %w", err)
+	}
+	if err := os.WriteFile(c.Output, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing questions file: %w", err)
+	}
+
+	questions := 0
+	for _, cat := range categories {
+		questions += len(cat.Questions)
+	}
+	fmt.Printf("Built %d categories with %d questions to %s\n", len(categories), questions, c.Output)
+	return nil
+}
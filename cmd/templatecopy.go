@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+type TemplatesCopyCmd struct {
+	Source      string `arg:"" name:"src-project" help:"Project ID to copy from"`
+	TemplateID  string `arg:"" help:"Template ID"`
+	Target      string `arg:"" name:"dst-project" help:"Project ID to copy to"`
+	Group       string `short:"g" help:"Template group ID in the target project"`
+	CreateGroup bool   `help:"Use the group with the same name as the source group in the target project, creating it if needed"`
+	Name        string `short:"n" help:"Name of the copy (default: the source name)"`
+	Publish     bool   `help:"Publish the copy"`
+	JSON        bool   `short:"j" help:"Output as JSON"`
+}
+
+func (c *TemplatesCopyCmd) Run(client *api.Client) error {
+	if (c.Group == "") == !c.CreateGroup {
+		return fmt.Errorf("use either --group or --create-group")
+	}
+
+	groupID := c.Group
+	if c.CreateGroup {
+		source, err := client.GetAuditTemplate(c.Source, c.TemplateID)
+		if err != nil {
+			return fmt.Errorf("getting template: %w", err)
+		}
+		if source.GroupID == "" {
+			return fmt.Errorf("template %s has no group, use --group", c.TemplateID)
+		}
+		groupID, err = targetTemplateGroup(client, c.Source, source.GroupID, c.Target)
+		if err != nil {
+			return err
+		}
+	}
+
+	copied, err := client.CopyAuditTemplate(api.CopyAuditTemplateOptions{
+		SourceDatabase: c.Source,
+		TemplateID:     c.TemplateID,
+		TargetDatabase: c.Target,
+		GroupID:        groupID,
+		Name:           c.Name,
+		Publish:        c.Publish,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		return printJSON(copied)
+	}
+
+	fmt.Printf("Template '%s' copied to %s (group '%s').\n", copied.Name, c.Target, copied.Group)
+	fmt.Printf("ID: %s\n", copied.ID)
+	warnDroppedAttachments(copied)
+	return nil
+}
+
+type TemplateGroupsCopyCmd struct {
+	Source   string `arg:"" name:"src-project" help:"Project ID to copy from"`
+	GroupID  string `arg:"" help:"Template group ID"`
+	Target   string `arg:"" name:"dst-project" help:"Project ID to copy to"`
+	Archived bool   `short:"a" help:"Also copy archived templates"`
+	Publish  bool   `help:"Publish the copies"`
+	JSON     bool   `short:"j" help:"Output as JSON"`
+}
+
+func (c *TemplateGroupsCopyCmd) Run(client *api.Client) error {
+	templates, _, err := client.ListAuditTemplates(api.ListAuditTemplatesOptions{
+		Database: c.Source,
+		GroupID:  c.GroupID,
+		Archived: c.Archived,
+		Size:     500,
+	})
+	if err != nil {
+		return fmt.Errorf("listing templates: %w", err)
+	}
+
+	groupID, err := targetTemplateGroup(client, c.Source, c.GroupID, c.Target)
+	if err != nil {
+		return err
+	}
+
+	var copied []*api.CopiedTemplate
+	var errs []string
+	for _, t := range templates {
+		templateID := firstNonEmpty(t.CouchDbID, t.CouchID, t.ID)
+		result, err := client.CopyAuditTemplate(api.CopyAuditTemplateOptions{
+			SourceDatabase: c.Source,
+			TemplateID:     templateID,
+			TargetDatabase: c.Target,
+			GroupID:        groupID,
+			Publish:        c.Publish,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s (%s): %v", t.Name, templateID, err))
+			continue
+		}
+		copied = append(copied, result)
+	}
+
+	if c.JSON {
+		if err := printJSON(copied); err != nil {
+			return err
+		}
+	} else if len(copied) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SOURCE ID\tNEW ID\tNAME")
+		fmt.Fprintln(w, "---------\t------\t----")
+		for _, t := range copied {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", t.SourceID, t.ID, t.Name)
+		}
+		w.Flush()
+		fmt.Printf("\nCopied %d of %d templates to group %s in %s.\n", len(copied), len(templates), groupID, c.Target)
+		for _, t := range copied {
+			warnDroppedAttachments(t)
+		}
+	} else {
+		fmt.Println("No templates copied.")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%d templates failed:\n  - %s", len(errs), strings.Join(errs, "\n  - "))
+	}
+	return nil
+}
+
+// targetTemplateGroup returns the group in the target project with the name of the
+// source group, creating it if there is none
+func targetTemplateGroup(client *api.Client, source, groupID, target string) (string, error) {
+	group, err := client.GetTemplateGroup(source, groupID)
+	if err != nil {
+		return "", fmt.Errorf("getting template group: %w", err)
+	}
+	if strings.TrimSpace(group.Name) == "" {
+		return "", fmt.Errorf("template group %s has no name", groupID)
+	}
+
+	id, created, err := client.FindOrCreateTemplateGroup(target, group.Name)
+	if err != nil {
+		return "", err
+	}
+	if created {
+		fmt.Fprintf(os.Stderr, "Created template group '%s' in %s (ID: %s)\n", group.Name, target, id)
+	}
+	return id, nil
+}
+
+func warnDroppedAttachments(t *api.CopiedTemplate) {
+	if len(t.DroppedAttachments) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: '%s' has attachments that couldn't be downloaded and were not copied: %s\n", t.Name, strings.Join(t.DroppedAttachments, ", "))
+	}
+}
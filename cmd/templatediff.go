@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+	"github.com/dutchview/edcontrols-cli/internal/snapshot"
+)
+
+type TemplatesDiffCmd struct {
+	Database       string `arg:"" name:"project-id" help:"Project ID"`
+	TemplateID     string `arg:"" help:"Template ID"`
+	Against        string `short:"a" required:"" help:"What to compare with: another template ID, a JSON/YAML questions, template or snapshot file, 'snapshot' (latest) or 'snapshot:N'"`
+	AgainstProject string `help:"Project of the --against template (default: the same project)"`
+	SnapshotDir    string `help:"Snapshot directory (default: ~/.config/edcontrols-cli/snapshots)" type:"path"`
+	JSON           bool   `short:"j" help:"Output as JSON"`
+}
+
+func (c *TemplatesDiffCmd) Run(client *api.Client) error {
+	doc, err := client.GetDocument(c.Database, c.TemplateID)
+	if err != nil {
+		return fmt.Errorf("getting template: %w", err)
+	}
+	after, err := documentQuestions(doc)
+	if err != nil {
+		return err
+	}
+
+	before, label, err := c.loadAgainst(client)
+	if err != nil {
+		return err
+	}
+
+	changes := api.DiffTemplateQuestions(before, after)
+
+	if c.JSON {
+		return printJSON(map[string]interface{}{
+			"template": c.TemplateID,
+			"against":  label,
+			"changes":  changes,
+		})
+	}
+
+	name, _ := doc["name"].(string)
+	fmt.Printf("Comparing %s → template '%s' (%s)\n\n", label, name, c.TemplateID)
+	if len(changes) == 0 {
+		fmt.Println("No differences.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHANGE\tCATEGORY\tQUESTION\tDETAILS")
+	fmt.Fprintln(w, "------\t--------\t--------\t-------")
+	for _, ch := range changes {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ch.Kind, truncate(ch.Category, 30), textOrDash(truncate(ch.Question, 50)), strings.Join(ch.Details, "; "))
+	}
+	w.Flush()
+	fmt.Printf("\n%d changes\n", len(changes))
+	return nil
+}
+
+// loadAgainst returns the questions to compare with and a description of them
+func (c *TemplatesDiffCmd) loadAgainst(client *api.Client) ([]api.TemplateCategory, string, error) {
+	if c.Against == "snapshot" || strings.HasPrefix(c.Against, "snapshot:") {
+		version := 0
+		if v := strings.TrimPrefix(c.Against, "snapshot"); v != "" {
+			n, err := strconv.Atoi(strings.TrimPrefix(v, ":"))
+			if err != nil || n < 1 {
+				return nil, "", fmt.Errorf("invalid snapshot version %q", v[1:])
+			}
+			version = n
+		}
+		snap, err := snapshot.Open(snapshotDir(c.SnapshotDir)).Get(c.Database, c.TemplateID, version)
+		if err != nil {
+			return nil, "", err
+		}
+		questions, err := documentQuestions(snap.Document)
+		if err != nil {
+			return nil, "", err
+		}
+		return questions, snapshotLabel(snap), nil
+	}
+
+	if _, err := os.Stat(c.Against); err == nil {
+		return loadQuestionsSource(c.Against)
+	}
+
+	database := firstNonEmpty(c.AgainstProject, c.Database)
+	doc, err := client.GetDocument(database, c.Against)
+	if err != nil {
+		return nil, "", fmt.Errorf("getting template %s: %w", c.Against, err)
+	}
+	questions, err := documentQuestions(doc)
+	if err != nil {
+		return nil, "", err
+	}
+	name, _ := doc["name"].(string)
+	return questions, fmt.Sprintf("template '%s' (%s)", name, c.Against), nil
+}
+
+// loadQuestionsSource reads a YAML questions file, or a JSON file with a questions
+// array, a template document or a snapshot
+func loadQuestionsSource(path string) ([]api.TemplateCategory, string, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yaml" || ext == ".yml" {
+		questions, err := api.LoadQuestionsFile(path)
+		return questions, path, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var questions []api.TemplateCategory
+	if err := json.Unmarshal(data, &questions); err == nil {
+		return questions, path, nil
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, "", fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if _, ok := doc["document"].(map[string]interface{}); ok {
+		snap, err := snapshot.Load(path)
+		if err != nil {
+			return nil, "", err
+		}
+		questions, err := documentQuestions(snap.Document)
+		return questions, snapshotLabel(snap), err
+	}
+	if _, ok := doc["questions"]; !ok {
+		return nil, "", fmt.Errorf("%s has no template questions", path)
+	}
+	questions, err = documentQuestions(doc)
+	return questions, path, err
+}
+
+// documentQuestions returns the questions of a template document
+func documentQuestions(doc map[string]interface{}) ([]api.TemplateCategory, error) {
+	data, err := json.Marshal(doc["questions"])
+	if err != nil {
+		return nil, fmt.Errorf("encoding questions: %w", err)
+	}
+	var questions []api.TemplateCategory
+	if err := json.Unmarshal(data, &questions); err != nil {
+		return nil, fmt.Errorf("parsing template questions: %w", err)
+	}
+	return questions, nil
+}
+
+func snapshotLabel(snap *snapshot.Snapshot) string {
+	return fmt.Sprintf("snapshot %d of %s", snap.Version, snap.TakenAt.Local().Format("2006-01-02 15:04"))
+}
+
+func snapshotDir(dir string) string {
+	if dir != "" {
+		return dir
+	}
+	return snapshot.DefaultDir()
+}
+
+type TemplatesSnapshotCmd struct {
+	Database   string `arg:"" name:"project-id" help:"Project ID"`
+	TemplateID string `arg:"" help:"Template ID"`
+	Message    string `short:"m" help:"Note to store with the snapshot"`
+	List       bool   `help:"List the stored snapshots instead of taking one"`
+	Dir        string `help:"Snapshot directory (default: ~/.config/edcontrols-cli/snapshots)" type:"path"`
+	JSON       bool   `short:"j" help:"Output as JSON"`
+}
+
+func (c *TemplatesSnapshotCmd) Run(client *api.Client) error {
+	store := snapshot.Open(snapshotDir(c.Dir))
+
+	if c.List {
+		snapshots, err := store.List(c.Database, c.TemplateID)
+		if err != nil {
+			return err
+		}
+		if c.JSON {
+			type listed struct {
+				Version int    `json:"version"`
+				TakenAt string `json:"takenAt"`
+				Name    string `json:"name"`
+				Rev     string `json:"rev"`
+				Message string `json:"message,omitempty"`
+				Path    string `json:"path"`
+			}
+			result := make([]listed, 0, len(snapshots))
+			for _, s := range snapshots {
+				result = append(result, listed{s.Version, s.TakenAt.Format("2006-01-02T15:04:05Z"), s.Name, s.Rev, s.Message, store.Path(c.Database, c.TemplateID, s.Version)})
+			}
+			return printJSON(result)
+		}
+		if len(snapshots) == 0 {
+			fmt.Println("No snapshots found.")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tTAKEN\tNAME\tREVISION\tMESSAGE")
+		fmt.Fprintln(w, "-------\t-----\t----\t--------\t-------")
+		for _, s := range snapshots {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", s.Version, s.TakenAt.Local().Format("2006-01-02 15:04"), truncate(s.Name, 40), truncate(s.Rev, 12), textOrDash(s.Message))
+		}
+		w.Flush()
+		return nil
+	}
+
+	doc, err := client.GetDocument(c.Database, c.TemplateID)
+	if err != nil {
+		return fmt.Errorf("getting template: %w", err)
+	}
+	if docType, _ := doc["type"].(string); docType != "IB.EdBundle.Document.AuditTemplate" {
+		return fmt.Errorf("%s is not an audit template", c.TemplateID)
+	}
+
+	snap, saved, err := store.Save(c.Database, c.TemplateID, doc, c.Message)
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		return printJSON(map[string]interface{}{
+			"version": snap.Version,
+			"saved":   saved,
+			"rev":     snap.Rev,
+			"path":    store.Path(c.Database, c.TemplateID, snap.Version),
+		})
+	}
+
+	if !saved {
+		fmt.Printf("Template unchanged since snapshot %d, nothing saved.\n", snap.Version)
+		return nil
+	}
+	fmt.Printf("Saved snapshot %d of '%s'.\n", snap.Version, snap.Name)
+	fmt.Printf("File: %s\n", store.Path(c.Database, c.TemplateID, snap.Version))
+	return nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+type TemplatesLintCmd struct {
+	Source     string `arg:"" name:"file-or-project" help:"Questions file (JSON or YAML), template document or snapshot file, or the project ID of a template"`
+	TemplateID string `arg:"" optional:"" help:"Template ID (when linting a template in a project)"`
+	Strict     bool   `help:"Also fail on warnings"`
+	JSON       bool   `short:"j" help:"Output as JSON"`
+}
+
+func (c *TemplatesLintCmd) Run(client *api.Client) error {
+	var categories []api.TemplateCategory
+	var label string
+	if c.TemplateID == "" {
+		var err error
+		categories, label, err = loadQuestionsSource(c.Source)
+		if err != nil {
+			return err
+		}
+	} else {
+		if client == nil {
+			return fmt.Errorf("linting a template needs the API configuration")
+		}
+		doc, err := client.GetDocument(c.Source, c.TemplateID)
+		if err != nil {
+			return fmt.Errorf("getting template: %w", err)
+		}
+		if categories, err = documentQuestions(doc); err != nil {
+			return err
+		}
+		name, _ := doc["name"].(string)
+		label = fmt.Sprintf("template '%s' (%s)", name, c.TemplateID)
+	}
+
+	issues := api.LintTemplateQuestions(categories)
+	errors, warnings := 0, 0
+	for _, issue := range issues {
+		if issue.Severity == api.SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+
+	if c.JSON {
+		if err := printJSON(issues); err != nil {
+			return err
+		}
+	} else if len(issues) == 0 {
+		fmt.Printf("%s: no problems found\n", label)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SEVERITY\tLOCATION\tMESSAGE")
+		fmt.Fprintln(w, "--------\t--------\t-------")
+		for _, issue := range issues {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", issue.Severity, textOrDash(issue.Location), issue.Message)
+		}
+		w.Flush()
+		fmt.Printf("\n%s: %d errors, %d warnings\n", label, errors, warnings)
+	}
+
+	if errors > 0 || (c.Strict && warnings > 0) {
+		return fmt.Errorf("lint failed with %d errors and %d warnings", errors, warnings)
+	}
+	return nil
+}
@@ -15,6 +15,14 @@ type TemplatesCmd struct {
 	Update    TemplatesUpdateCmd    `cmd:"" help:"Update an audit template"`
 	Publish   TemplatesPublishCmd   `cmd:"" help:"Publish an audit template"`
 	Unpublish TemplatesUnpublishCmd `cmd:"" help:"Unpublish an audit template"`
+	Tags      TemplatesTagsCmd      `cmd:"" help:"Show or change template tags (--add, --remove, --set, --clear)"`
+	Build     TemplatesBuildCmd     `cmd:"" help:"Compile a YAML questions file to template questions JSON"`
+	Copy      TemplatesCopyCmd      `cmd:"" help:"Copy a template to a group in another project (--group or --create-group)"`
+	Diff      TemplatesDiffCmd      `cmd:"" help:"Compare a template with another template, a file or a snapshot (--against)"`
+	Snapshot  TemplatesSnapshotCmd  `cmd:"" help:"Store a local versioned copy of a template (--list to show them)"`
+	Lint      TemplatesLintCmd      `cmd:"" help:"Check a questions file or template for errors and warnings"`
+	Import    TemplatesImportCmd    `cmd:"" help:"Create or update a template from a CSV or xlsx checklist"`
+	Export    TemplatesExportCmd    `cmd:"" help:"Export the questions of a template as a CSV or xlsx checklist"`
 	Groups    TemplateGroupsCmd     `cmd:"" help:"Manage template groups"`
 }
 
@@ -27,6 +35,7 @@ type TemplateGroupsCmd struct {
 	Unarchive TemplateGroupsUnarchiveCmd `cmd:"" help:"Unarchive a template group"`
 	Delete    TemplateGroupsDeleteCmd    `cmd:"" help:"Delete a template group (soft delete)"`
 	Undelete  TemplateGroupsUndeleteCmd  `cmd:"" help:"Restore a deleted template group"`
+	Copy      TemplateGroupsCopyCmd      `cmd:"" help:"Copy a template group with its templates to another project"`
 }
 
 type TemplateGroupsListCmd struct {
@@ -354,7 +363,7 @@ type TemplatesCreateCmd struct {
 	GroupID       string   `arg:"" help:"Template group ID"`
 	Name          string   `arg:"" help:"Template name"`
 	Tags          []string `short:"t" help:"Tags to add (can be specified multiple times)"`
-	QuestionsFile string   `short:"q" name:"questions-file" help:"Path to a JSON file containing template questions"`
+	QuestionsFile string   `short:"q" name:"questions-file" help:"Path to a JSON or YAML (see templates build) file containing template questions"`
 	JSON          bool     `short:"j" help:"Output as JSON"`
 }
 
@@ -398,7 +407,7 @@ type TemplatesUpdateCmd struct {
 	Name          string   `short:"n" help:"New template name"`
 	Description   string   `short:"d" help:"New description"`
 	Tags          []string `short:"t" help:"Tags to set (replaces existing)"`
-	QuestionsFile string   `short:"q" name:"questions-file" help:"Path to a JSON file containing template questions"`
+	QuestionsFile string   `short:"q" name:"questions-file" help:"Path to a JSON or YAML (see templates build) file containing template questions"`
 }
 
 func (c *TemplatesUpdateCmd) Run(client *api.Client) error {
@@ -458,3 +467,13 @@ func (c *TemplatesUnpublishCmd) Run(client *api.Client) error {
 	fmt.Printf("Template %s unpublished.\n", c.TemplateID)
 	return nil
 }
+
+type TemplatesTagsCmd struct {
+	Database     string `arg:"" name:"project-id" help:"Project ID"`
+	TemplateID   string `arg:"" help:"Template ID"`
+	TagEditFlags `embed:""`
+}
+
+func (c *TemplatesTagsCmd) Run(client *api.Client) error {
+	return runTagEdit(client, c.Database, c.TemplateID, "template", c.TemplateID, c.edit())
+}
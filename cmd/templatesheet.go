@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+	"github.com/dutchview/edcontrols-cli/internal/xlsx"
+)
+
+type TemplatesImportCmd struct {
+	File     string `arg:"" type:"existingfile" help:"Checklist file (.csv or .xlsx)"`
+	Database string `arg:"" name:"project-id" help:"Project ID"`
+	GroupID  string `arg:"" help:"Template group ID"`
+	Name     string `short:"n" help:"Template name (default: the file name without extension)"`
+	Template string `short:"t" help:"ID of the template to update (default: the template in the group with the same name, or a new one)"`
+	DryRun   bool   `help:"Validate the checklist and show what would be done without saving"`
+	JSON     bool   `short:"j" help:"Output as JSON"`
+}
+
+func (c *TemplatesImportCmd) Run(client *api.Client) error {
+	rows, err := readChecklist(c.File)
+	if err != nil {
+		return err
+	}
+	categories, err := api.ParseTemplateSheet(rows)
+	if err != nil {
+		return err
+	}
+	for _, issue := range api.LintTemplateQuestions(categories) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", issue)
+	}
+
+	name := c.Name
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(c.File), filepath.Ext(c.File))
+	}
+
+	templateID := c.Template
+	if templateID == "" {
+		templates, _, err := client.ListAuditTemplates(api.ListAuditTemplatesOptions{
+			Database:   c.Database,
+			GroupID:    c.GroupID,
+			SearchName: name,
+			Size:       100,
+		})
+		if err != nil {
+			return fmt.Errorf("listing templates: %w", err)
+		}
+		for _, t := range templates {
+			if strings.EqualFold(strings.TrimSpace(t.Name), strings.TrimSpace(name)) {
+				templateID = firstNonEmpty(t.CouchDbID, t.CouchID, t.ID)
+				break
+			}
+		}
+	}
+
+	action := "create"
+	if templateID != "" {
+		action = "update"
+	}
+
+	if !c.DryRun {
+		if action == "update" {
+			// Keep option IDs and formatted texts of questions that are still there
+			doc, err := client.GetDocument(c.Database, templateID)
+			if err != nil {
+				return fmt.Errorf("getting template: %w", err)
+			}
+			current, err := documentQuestions(doc)
+			if err != nil {
+				return err
+			}
+			api.MergeTemplateSheet(current, categories)
+
+			updates := map[string]interface{}{"questions": categories}
+			if c.Name != "" {
+				updates["name"] = c.Name
+			}
+			if err := client.UpdateAuditTemplate(c.Database, templateID, updates); err != nil {
+				return fmt.Errorf("updating template: %w", err)
+			}
+		} else {
+			templateID, err = client.CreateAuditTemplate(api.CreateAuditTemplateOptions{
+				Database:  c.Database,
+				GroupID:   c.GroupID,
+				Name:      name,
+				Questions: categories,
+			})
+			if err != nil {
+				return fmt.Errorf("creating template: %w", err)
+			}
+		}
+	}
+
+	questions := 0
+	for _, cat := range categories {
+		questions += len(cat.Questions)
+	}
+
+	if c.JSON {
+		return printJSON(map[string]interface{}{
+			"id":         templateID,
+			"name":       name,
+			"action":     action,
+			"dryRun":     c.DryRun,
+			"categories": len(categories),
+			"questions":  questions,
+		})
+	}
+
+	if c.DryRun {
+		if action == "update" {
+			fmt.Printf("Would update the questions of template '%s' (%s): %d categories, %d questions.\n", name, templateID, len(categories), questions)
+		} else {
+			fmt.Printf("Would create template '%s': %d categories, %d questions.\n", name, len(categories), questions)
+		}
+		fmt.Println("Dry run, nothing was saved.")
+		return nil
+	}
+
+	done := "created"
+	if action == "update" {
+		done = "updated"
+	}
+	fmt.Printf("Template '%s' %s with %d categories and %d questions.\n", name, done, len(categories), questions)
+	fmt.Printf("ID: %s\n", templateID)
+	return nil
+}
+
+// readChecklist reads the rows of a CSV or xlsx checklist
+func readChecklist(path string) ([][]string, error) {
+	if strings.EqualFold(filepath.Ext(path), ".xlsx") {
+		return xlsx.ReadFile(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening checklist: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing checklist: %w", err)
+	}
+	return rows, nil
+}
+
+type TemplatesExportCmd struct {
+	Database   string `arg:"" name:"project-id" help:"Project ID"`
+	TemplateID string `arg:"" help:"Template ID"`
+	Format     string `short:"f" enum:"csv,xlsx" default:"csv" help:"Output format (csv, xlsx)"`
+	Output     string `short:"o" help:"Output file (default: template-<template human ID>.<format>, '-' for stdout)"`
+}
+
+func (c *TemplatesExportCmd) Run(client *api.Client) error {
+	doc, err := client.GetDocument(c.Database, c.TemplateID)
+	if err != nil {
+		return fmt.Errorf("getting template: %w", err)
+	}
+	categories, err := documentQuestions(doc)
+	if err != nil {
+		return err
+	}
+	rows := api.TemplateSheet(categories)
+
+	output := c.Output
+	if output == "" {
+		output = fmt.Sprintf("template-%s.%s", humanID(c.TemplateID), c.Format)
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "-" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("creating export file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	name, _ := doc["name"].(string)
+	if c.Format == "xlsx" {
+		err = xlsx.Write(w, firstNonEmpty(name, "Template"), rows)
+	} else {
+		err = writeMatrixCSV(w, rows)
+	}
+	if err != nil {
+		return err
+	}
+
+	if output != "-" {
+		questions := 0
+		for _, cat := range categories {
+			questions += len(cat.Questions)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d questions of '%s' to %s\n", questions, name, output)
+	}
+	return nil
+}
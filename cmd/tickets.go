@@ -8,21 +8,29 @@ import (
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/dutchview/edcontrols-cli/internal/api"
+	"github.com/dutchview/edcontrols-cli/internal/idcache"
 )
 
 type TicketsCmd struct {
-	List        TicketsListCmd        `cmd:"" help:"List tickets"`
-	Get         TicketsGetCmd         `cmd:"" help:"Get ticket details"`
-	Update      TicketsUpdateCmd      `cmd:"" help:"Update ticket fields (-t title, -d description, --due-date, --clear-due, -r responsible, --clear-responsible, --complete, -m comment)"`
-	Assign      TicketsAssignCmd      `cmd:"" help:"Assign a ticket to someone"`
-	Open        TicketsOpenCmd        `cmd:"" help:"Reopen a ticket (set status to created)"`
-	Close       TicketsCloseCmd       `cmd:"" help:"Close a ticket (set status to completed)"`
-	Archive     TicketsArchiveCmd     `cmd:"" help:"Archive a ticket"`
-	Unarchive   TicketsUnarchiveCmd   `cmd:"" help:"Unarchive a ticket"`
-	Delete      TicketsDeleteCmd      `cmd:"" help:"Delete a ticket"`
-	Attachments TicketAttachmentsCmd  `cmd:"" help:"List or download ticket attachments (photos)"`
+	List         TicketsListCmd         `cmd:"" help:"List tickets"`
+	Get          TicketsGetCmd          `cmd:"" help:"Get ticket details"`
+	Create       TicketsCreateCmd       `cmd:"" help:"Create a new ticket (-t title, -d description, -r responsible, --due, --tag, --map/--x/--y pin, --photo)"`
+	Update       TicketsUpdateCmd       `cmd:"" help:"Update ticket fields (-t title, -d description, --due-date, --clear-due, -r responsible, --clear-responsible, --complete, -m comment)"`
+	Assign       TicketsAssignCmd       `cmd:"" help:"Assign a ticket to someone"`
+	Open         TicketsOpenCmd         `cmd:"" help:"Reopen a completed ticket (status back to started, or created without a responsible)"`
+	Close        TicketsCloseCmd        `cmd:"" help:"Close a ticket (set status to completed and record the completion date)"`
+	Archive      TicketsArchiveCmd      `cmd:"" help:"Archive a ticket"`
+	Unarchive    TicketsUnarchiveCmd    `cmd:"" help:"Unarchive a ticket"`
+	Delete       TicketsDeleteCmd       `cmd:"" help:"Delete a ticket"`
+	Attachments  TicketAttachmentsCmd   `cmd:"" help:"List or download ticket attachments (photos)"`
+	Comments     TicketCommentsCmd      `cmd:"" help:"List, add, edit or delete ticket comments"`
+	Participants TicketsParticipantsCmd `cmd:"" help:"Show or change informed and consulted participants (--inform, --consult, --remove)"`
+	Tags         TicketsTagsCmd         `cmd:"" help:"Show or change ticket tags (--add, --remove, --set, --clear)"`
+	Bulk         TicketsBulkCmd         `cmd:"" help:"Apply an operation to all tickets matching filters (assign, close, reopen, archive, tag-add, tag-replace, delete)"`
+	History      TicketsHistoryCmd      `cmd:"" help:"Show the change history of a ticket (--author, --property, --platform, -f table|json|diff)"`
 }
 
 type TicketsListCmd struct {
@@ -47,34 +55,9 @@ type TicketsListCmd struct {
 
 func (c *TicketsListCmd) Run(client *api.Client) error {
 	// Parse date filters
-	var filters DateFilterSet
-	if c.CreatedAfter != "" {
-		t, err := ParseRelativeTime(c.CreatedAfter)
-		if err != nil {
-			return fmt.Errorf("--created-after: %w", err)
-		}
-		filters.CreatedAfter = &t
-	}
-	if c.CreatedBefore != "" {
-		t, err := ParseRelativeTime(c.CreatedBefore)
-		if err != nil {
-			return fmt.Errorf("--created-before: %w", err)
-		}
-		filters.CreatedBefore = &t
-	}
-	if c.ModifiedAfter != "" {
-		t, err := ParseRelativeTime(c.ModifiedAfter)
-		if err != nil {
-			return fmt.Errorf("--modified-after: %w", err)
-		}
-		filters.ModifiedAfter = &t
-	}
-	if c.ModifiedBefore != "" {
-		t, err := ParseRelativeTime(c.ModifiedBefore)
-		if err != nil {
-			return fmt.Errorf("--modified-before: %w", err)
-		}
-		filters.ModifiedBefore = &t
+	filters, err := ParseDateFilters(c.CreatedAfter, c.CreatedBefore, c.ModifiedAfter, c.ModifiedBefore)
+	if err != nil {
+		return err
 	}
 
 	hasDateFilters := filters.HasDateFilters()
@@ -207,6 +190,7 @@ func (c *TicketsListCmd) Run(client *api.Client) error {
 			if err != nil {
 				continue // Skip projects with errors
 			}
+			rememberTickets(project.ProjectID, tickets)
 
 			// Track which project each ticket belongs to and apply date filter
 			for _, t := range tickets {
@@ -235,6 +219,10 @@ func (c *TicketsListCmd) Run(client *api.Client) error {
 		total = len(allTickets)
 	}
 
+	if c.Database != "" {
+		rememberTickets(c.Database, allTickets)
+	}
+
 	if c.JSON {
 		return printJSON(allTickets)
 	}
@@ -307,25 +295,9 @@ type TicketsGetCmd struct {
 }
 
 func (c *TicketsGetCmd) Run(client *api.Client) error {
-	database := c.Database
-	ticketID := c.TicketID
-
-	// If the ticket ID looks like a human ID (6 chars or less), search for it
-	isHumanID := len(c.TicketID) <= 6
-
-	if isHumanID {
-		// Search for the ticket by human ID
-		var searchDB string
-		if c.Database != "" {
-			// Search only in specified database
-			searchDB = c.Database
-		}
-		foundDB, foundID, err := findTicketByHumanID(client, c.TicketID, searchDB)
-		if err != nil {
-			return err
-		}
-		database = foundDB
-		ticketID = foundID
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
 	}
 
 	if c.JSON {
@@ -341,6 +313,7 @@ func (c *TicketsGetCmd) Run(client *api.Client) error {
 	if err != nil {
 		return err
 	}
+	rememberTickets(database, []api.Ticket{*ticket})
 
 	title := "-"
 	if ticket.Content != nil && ticket.Content.Title != "" {
@@ -383,6 +356,12 @@ func (c *TicketsGetCmd) Run(client *api.Client) error {
 	if ticket.Participants != nil && ticket.Participants.Responsible != nil && ticket.Participants.Responsible.Email != "" {
 		fmt.Printf("Responsible: %s\n", ticket.Participants.Responsible.Email)
 	}
+	if ticket.Participants != nil && len(ticket.Participants.Informed) > 0 {
+		fmt.Printf("Informed: %s\n", personEmailList(ticket.Participants.Informed))
+	}
+	if ticket.Participants != nil && len(ticket.Participants.Consulted) > 0 {
+		fmt.Printf("Consulted: %s\n", personEmailList(ticket.Participants.Consulted))
+	}
 
 	if ticket.Dates != nil {
 		if ticket.Dates.DueDate != "" {
@@ -411,119 +390,166 @@ func (c *TicketsGetCmd) Run(client *api.Client) error {
 	return nil
 }
 
+type TicketsCreateCmd struct {
+	Database    string   `arg:"" name:"project-id" help:"Project ID"`
+	Title       string   `short:"t" required:"" help:"Ticket title"`
+	Description string   `short:"d" help:"Ticket description (supports HTML)"`
+	Responsible string   `short:"r" help:"Assign to this email (sets status to started)"`
+	Due         string   `help:"Due date (e.g., 3d, 2w, 1mo from now, 2026-03-15, or ISO 8601)"`
+	Tags        []string `name:"tag" help:"Tags to add (can be specified multiple times)"`
+	MapID       string   `name:"map" help:"Map ID to pin the ticket on (human ID or full CouchDB ID)"`
+	X           *float64 `name:"x" help:"X position of the pin on the map"`
+	Y           *float64 `name:"y" help:"Y position of the pin on the map"`
+	Photos      []string `name:"photo" type:"existingfile" help:"Photo to attach (can be specified multiple times)"`
+	JSON        bool     `short:"j" help:"Output as JSON"`
+}
+
+func (c *TicketsCreateCmd) Run(client *api.Client) error {
+	if (c.X != nil || c.Y != nil) && c.MapID == "" {
+		return fmt.Errorf("--x and --y require --map")
+	}
+	if c.MapID != "" && (c.X == nil || c.Y == nil) {
+		return fmt.Errorf("--map requires both --x and --y to position the pin")
+	}
+
+	opts := api.CreateTicketOptions{
+		Database:    c.Database,
+		Title:       c.Title,
+		Responsible: c.Responsible,
+		Tags:        c.Tags,
+		MapID:       c.MapID,
+	}
+	if c.Due != "" {
+		due, err := ParseDueDate(c.Due, time.Now())
+		if err != nil {
+			return fmt.Errorf("--due: %w", err)
+		}
+		opts.DueDate = due
+	}
+	if c.Description != "" {
+		// Sanitize HTML to prevent XSS attacks
+		opts.Description = sanitizeHTML(c.Description)
+	}
+	if c.MapID != "" {
+		_, mapID, err := resolveMapID(client, c.Database, c.MapID)
+		if err != nil {
+			return err
+		}
+		opts.MapID = mapID
+		opts.X = *c.X
+		opts.Y = *c.Y
+	}
+
+	photos, err := loadAttachmentFiles(c.Photos)
+	if err != nil {
+		return err
+	}
+	opts.Photos = photos
+
+	ticketID, err := client.CreateTicket(opts)
+	if err != nil {
+		return fmt.Errorf("creating ticket: %w", err)
+	}
+	rememberIDs(kindTicket, idcache.Entry{Database: c.Database, ID: ticketID, Label: c.Title})
+
+	if c.JSON {
+		return printJSON(map[string]string{
+			"id":       ticketID,
+			"humanId":  humanID(ticketID),
+			"database": c.Database,
+		})
+	}
+
+	fmt.Printf("Ticket created: %s\n", humanID(ticketID))
+	fmt.Printf("ID: %s\n", ticketID)
+	if len(photos) > 0 {
+		fmt.Printf("Photos: %d attached\n", len(photos))
+	}
+	return nil
+}
+
 type TicketsAssignCmd struct {
 	Database    string `arg:"" name:"project-id" help:"Project ID"`
-	TicketID    string `arg:"" help:"Ticket ID"`
+	TicketID    string `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
 	Responsible string `arg:"" help:"Email of the person to assign"`
 }
 
 func (c *TicketsAssignCmd) Run(client *api.Client) error {
-	opts := api.UpdateTicketOptions{
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	opts := api.UpdateTicketFieldsOptions{
 		Responsible: &c.Responsible,
 	}
 
-	if err := client.UpdateTicket(c.Database, c.TicketID, opts); err != nil {
-		return err
+	if err := client.UpdateTicketFields(database, ticketID, opts); err != nil {
+		return fmt.Errorf("assigning ticket: %w", err)
 	}
 
-	fmt.Printf("Ticket %s assigned to %s\n", c.TicketID, c.Responsible)
+	fmt.Printf("Ticket %s assigned to %s\n", humanID(ticketID), c.Responsible)
 	return nil
 }
 
 type TicketsOpenCmd struct {
 	Database string `arg:"" name:"project-id" help:"Project ID"`
-	TicketID string `arg:"" help:"Ticket ID"`
+	TicketID string `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
 }
 
 func (c *TicketsOpenCmd) Run(client *api.Client) error {
-	status := "created"
-	opts := api.UpdateTicketOptions{
-		Status: &status,
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	opts := api.UpdateTicketFieldsOptions{
+		Reopen: true,
+	}
+
+	if err := client.UpdateTicketFields(database, ticketID, opts); err != nil {
+		return fmt.Errorf("reopening ticket: %w", err)
 	}
 
-	if err := client.UpdateTicket(c.Database, c.TicketID, opts); err != nil {
+	ticket, err := client.GetTicket(database, ticketID)
+	if err != nil {
 		return err
 	}
+	status := "-"
+	if ticket.State != nil && ticket.State.State != "" {
+		status = ticket.State.State
+	}
 
-	fmt.Printf("Ticket %s reopened (status: created)\n", c.TicketID)
+	fmt.Printf("Ticket %s reopened (status: %s)\n", humanID(ticketID), status)
 	return nil
 }
 
 type TicketsCloseCmd struct {
 	Database string `arg:"" name:"project-id" help:"Project ID"`
-	TicketID string `arg:"" help:"Ticket ID"`
+	TicketID string `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
 }
 
 func (c *TicketsCloseCmd) Run(client *api.Client) error {
-	status := "completed"
-	opts := api.UpdateTicketOptions{
-		Status: &status,
-	}
-
-	if err := client.UpdateTicket(c.Database, c.TicketID, opts); err != nil {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Ticket %s closed (status: completed)\n", c.TicketID)
-	return nil
-}
-
-// findTicketByHumanID searches for a ticket matching the given human ID.
-// If limitToDatabase is not empty, only searches that database.
-// Returns the database name and full CouchDB ID of the ticket.
-func findTicketByHumanID(client *api.Client, searchID string, limitToDatabase string) (string, string, error) {
-	searchID = strings.ToUpper(searchID)
-
-	var projectIDs []string
-
-	if limitToDatabase != "" {
-		projectIDs = []string{limitToDatabase}
-	} else {
-		projects, _, err := client.ListProjects(api.ListProjectsOptions{})
-		if err != nil {
-			return "", "", err
-		}
-		for _, project := range projects {
-			// Skip glacier projects and inactive projects for faster search
-			if project.ProjectID == "glacier_project_documents" || !project.IsActive {
-				continue
-			}
-			projectIDs = append(projectIDs, project.ProjectID)
-		}
-	}
-
-	// Use the POST search endpoint which supports searchById across multiple projects
-	// The API searches for IDs ending with the value or its reverse (case-insensitive)
-	// Human ID is reversed last 6 chars, so we search with lowercase to match
-	tickets, err := client.SearchTicketsByID(projectIDs, strings.ToLower(searchID))
-	if err != nil {
-		return "", "", err
+	opts := api.UpdateTicketFieldsOptions{
+		Complete: true,
 	}
 
-	// Verify the human ID matches and extract the database from the ticket
-	for _, ticket := range tickets {
-		if humanID(ticket.CouchDbID) == searchID {
-			// Extract database from the ticket's ID field (format: database|couchDbId)
-			if ticket.ID != "" && strings.Contains(ticket.ID, "|") {
-				parts := strings.SplitN(ticket.ID, "|", 2)
-				return parts[0], ticket.CouchDbID, nil
-			}
-			// Fallback: search each project to find where this ticket exists
-			for _, projectID := range projectIDs {
-				_, err := client.GetTicket(projectID, ticket.CouchDbID)
-				if err == nil {
-					return projectID, ticket.CouchDbID, nil
-				}
-			}
-		}
+	if err := client.UpdateTicketFields(database, ticketID, opts); err != nil {
+		return fmt.Errorf("closing ticket: %w", err)
 	}
 
-	return "", "", fmt.Errorf("ticket with ID %s not found", searchID)
+	fmt.Printf("Ticket %s closed (status: completed)\n", humanID(ticketID))
+	return nil
 }
 
 type TicketsUpdateCmd struct {
 	Database         string `arg:"" name:"project-id" help:"Project ID"`
-	TicketID         string `arg:"" help:"Ticket ID"`
+	TicketID         string `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
 	Title            string `short:"t" help:"New title for the ticket"`
 	Description      string `short:"d" help:"New description for the ticket"`
 	DueDate          string `help:"Due date (ISO 8601 format, e.g., 2026-03-15T12:00:00.000Z)"`
@@ -531,15 +557,22 @@ type TicketsUpdateCmd struct {
 	Responsible      string `short:"r" help:"Assign to this email (also sets status to started)"`
 	ClearResponsible bool   `help:"Clear the responsible person (sets status back to created)"`
 	Complete         bool   `help:"Mark ticket as completed (uses existing responsible or current user)"`
+	Reopen           bool   `help:"Reopen a completed ticket (status back to started, or created without responsible)"`
 	Comment          string `short:"m" help:"Add a comment to the ticket"`
 }
 
 func (c *TicketsUpdateCmd) Run(client *api.Client) error {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
 	// Build update options
 	opts := api.UpdateTicketFieldsOptions{
 		ClearDue:         c.ClearDue,
 		ClearResponsible: c.ClearResponsible,
 		Complete:         c.Complete,
+		Reopen:           c.Reopen,
 	}
 
 	if c.Title != "" {
@@ -563,8 +596,8 @@ func (c *TicketsUpdateCmd) Run(client *api.Client) error {
 	}
 
 	// If no updates specified, show current values
-	if opts.Title == nil && opts.Description == nil && opts.DueDate == nil && !opts.ClearDue && opts.Responsible == nil && !opts.ClearResponsible && !opts.Complete && opts.Comment == nil {
-		ticket, err := client.GetTicket(c.Database, c.TicketID)
+	if opts.Title == nil && opts.Description == nil && opts.DueDate == nil && !opts.ClearDue && opts.Responsible == nil && !opts.ClearResponsible && !opts.Complete && !opts.Reopen && opts.Comment == nil {
+		ticket, err := client.GetTicket(database, ticketID)
 		if err != nil {
 			return fmt.Errorf("getting ticket: %w", err)
 		}
@@ -585,7 +618,7 @@ func (c *TicketsUpdateCmd) Run(client *api.Client) error {
 			dueDate = ticket.Dates.DueDate
 		} else {
 			// Check plan.dueDate via raw document
-			dd, _ := client.GetTicketDueDate(c.Database, c.TicketID)
+			dd, _ := client.GetTicketDueDate(database, ticketID)
 			if dd != "" {
 				dueDate = dd
 			}
@@ -605,7 +638,7 @@ func (c *TicketsUpdateCmd) Run(client *api.Client) error {
 		return nil
 	}
 
-	if err := client.UpdateTicketFields(c.Database, c.TicketID, opts); err != nil {
+	if err := client.UpdateTicketFields(database, ticketID, opts); err != nil {
 		return fmt.Errorf("updating ticket: %w", err)
 	}
 
@@ -633,58 +666,93 @@ func (c *TicketsUpdateCmd) Run(client *api.Client) error {
 	if opts.ClearResponsible {
 		updates = append(updates, "responsible cleared (status->created)")
 	}
+	if opts.Reopen && opts.Responsible == nil && !opts.ClearResponsible {
+		updates = append(updates, "reopened")
+	}
 	if opts.Comment != nil {
 		updates = append(updates, fmt.Sprintf("comment added: %q", truncate(*opts.Comment, 50)))
 	}
 
-	fmt.Printf("Ticket %s updated: %s\n", c.TicketID, strings.Join(updates, ", "))
+	fmt.Printf("Ticket %s updated: %s\n", humanID(ticketID), strings.Join(updates, ", "))
 	return nil
 }
 
 type TicketsArchiveCmd struct {
 	Database string `arg:"" name:"project-id" help:"Project ID"`
-	TicketID string `arg:"" help:"Ticket ID"`
+	TicketID string `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
 }
 
 func (c *TicketsArchiveCmd) Run(client *api.Client) error {
-	if err := client.ArchiveTicket(c.Database, c.TicketID, true); err != nil {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.ArchiveTicket(database, ticketID, true); err != nil {
 		return fmt.Errorf("archiving ticket: %w", err)
 	}
-	fmt.Printf("Ticket %s archived.\n", c.TicketID)
+	fmt.Printf("Ticket %s archived.\n", humanID(ticketID))
 	return nil
 }
 
 type TicketsUnarchiveCmd struct {
 	Database string `arg:"" name:"project-id" help:"Project ID"`
-	TicketID string `arg:"" help:"Ticket ID"`
+	TicketID string `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
 }
 
 func (c *TicketsUnarchiveCmd) Run(client *api.Client) error {
-	if err := client.ArchiveTicket(c.Database, c.TicketID, false); err != nil {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.ArchiveTicket(database, ticketID, false); err != nil {
 		return fmt.Errorf("unarchiving ticket: %w", err)
 	}
-	fmt.Printf("Ticket %s unarchived.\n", c.TicketID)
+	fmt.Printf("Ticket %s unarchived.\n", humanID(ticketID))
 	return nil
 }
 
 type TicketsDeleteCmd struct {
 	Database string `arg:"" name:"project-id" help:"Project ID"`
-	TicketID string `arg:"" help:"Ticket ID"`
+	TicketID string `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
 }
 
 func (c *TicketsDeleteCmd) Run(client *api.Client) error {
-	if err := client.DeleteTickets(c.Database, []string{c.TicketID}); err != nil {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteTickets(database, []string{ticketID}); err != nil {
 		return fmt.Errorf("deleting ticket: %w", err)
 	}
-	fmt.Printf("Ticket %s deleted.\n", c.TicketID)
+	forgetID(kindTicket, ticketID)
+	fmt.Printf("Ticket %s deleted.\n", humanID(ticketID))
 	return nil
 }
 
+type TicketsTagsCmd struct {
+	TicketID     string `arg:"" help:"Ticket ID (human ID like 'CC455B' or full CouchDB ID)"`
+	Database     string `short:"p" name:"project" help:"Project ID (optional, will search if not provided)"`
+	TagEditFlags `embed:""`
+}
+
+func (c *TicketsTagsCmd) Run(client *api.Client) error {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	return runTagEdit(client, database, ticketID, "ticket", humanID(ticketID), c.edit())
+}
+
 // --- Ticket Attachments ---
 
 type TicketAttachmentsCmd struct {
 	List     TicketAttachmentsListCmd     `cmd:"" help:"List attachments on a ticket"`
 	Download TicketAttachmentsDownloadCmd `cmd:"" help:"Download attachments from a ticket"`
+	Add      TicketAttachmentsAddCmd      `cmd:"" help:"Upload photos or documents to a ticket"`
 }
 
 type TicketAttachmentsListCmd struct {
@@ -694,16 +762,9 @@ type TicketAttachmentsListCmd struct {
 }
 
 func (c *TicketAttachmentsListCmd) Run(client *api.Client) error {
-	database := c.Database
-	ticketID := c.TicketID
-
-	if len(c.TicketID) <= 6 {
-		foundDB, foundID, err := findTicketByHumanID(client, c.TicketID, c.Database)
-		if err != nil {
-			return err
-		}
-		database = foundDB
-		ticketID = foundID
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
 	}
 
 	doc, err := client.GetDocument(database, ticketID)
@@ -738,16 +799,9 @@ func (c *TicketAttachmentsDownloadCmd) Run(client *api.Client) error {
 		return fmt.Errorf("specify an attachment name or use --all to download all attachments")
 	}
 
-	database := c.Database
-	ticketID := c.TicketID
-
-	if len(c.TicketID) <= 6 {
-		foundDB, foundID, err := findTicketByHumanID(client, c.TicketID, c.Database)
-		if err != nil {
-			return err
-		}
-		database = foundDB
-		ticketID = foundID
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
 	}
 
 	doc, err := client.GetDocument(database, ticketID)
@@ -779,6 +833,168 @@ func (c *TicketAttachmentsDownloadCmd) Run(client *api.Client) error {
 	return downloadAttachment(client, database, ticketID, c.Name, c.Output)
 }
 
+// --- Ticket Comments ---
+
+type TicketCommentsCmd struct {
+	List   TicketCommentsListCmd   `cmd:"" help:"Show the comment thread of a ticket"`
+	Add    TicketCommentsAddCmd    `cmd:"" help:"Add a comment to a ticket"`
+	Edit   TicketCommentsEditCmd   `cmd:"" help:"Edit a comment"`
+	Delete TicketCommentsDeleteCmd `cmd:"" help:"Delete a comment"`
+}
+
+type TicketCommentsListCmd struct {
+	Database string `arg:"" name:"project-id" help:"Project ID"`
+	TicketID string `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
+	JSON     bool   `short:"j" help:"Output as JSON"`
+}
+
+func (c *TicketCommentsListCmd) Run(client *api.Client) error {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	comments, err := client.ListComments(database, ticketID)
+	if err != nil {
+		return fmt.Errorf("getting comments: %w", err)
+	}
+
+	if c.JSON {
+		return printJSON(comments)
+	}
+
+	if len(comments) == 0 {
+		fmt.Println("No comments.")
+		return nil
+	}
+
+	for _, cm := range comments {
+		when := cm.Time
+		if t, err := parseAPIDate(cm.Time); err == nil {
+			when = t.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Printf("#%d  %s  %s", cm.Index, cm.Author, when)
+		if !cm.Public {
+			if len(cm.AllowedUsers) > 0 {
+				fmt.Printf("  [private: %s]", strings.Join(cm.AllowedUsers, ", "))
+			} else {
+				fmt.Printf("  [private]")
+			}
+		}
+		fmt.Println()
+		for _, line := range strings.Split(cm.Note, "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+		if len(cm.Attachments) > 0 {
+			fmt.Printf("    Attachments: %s\n", strings.Join(cm.Attachments, ", "))
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("Total: %d comments\n", len(comments))
+	return nil
+}
+
+type TicketCommentsAddCmd struct {
+	Database string   `arg:"" name:"project-id" help:"Project ID"`
+	TicketID string   `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
+	Note     string   `arg:"" help:"Comment text"`
+	Private  bool     `help:"Make the comment private (only visible to --allow users)"`
+	Allow    []string `help:"Email allowed to see a private comment (can be specified multiple times)"`
+	Photos   []string `name:"photo" type:"existingfile" help:"Photo to attach (can be specified multiple times)"`
+}
+
+func (c *TicketCommentsAddCmd) Run(client *api.Client) error {
+	if len(c.Allow) > 0 && !c.Private {
+		return fmt.Errorf("--allow requires --private")
+	}
+
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	photos, err := loadAttachmentFiles(c.Photos)
+	if err != nil {
+		return err
+	}
+
+	opts := api.AddCommentOptions{
+		// Sanitize HTML in comment to prevent XSS
+		Note:         sanitizeHTML(c.Note),
+		Private:      c.Private,
+		AllowedUsers: c.Allow,
+		Attachments:  photos,
+	}
+
+	if err := client.AddComment(database, ticketID, opts); err != nil {
+		return fmt.Errorf("adding comment: %w", err)
+	}
+
+	fmt.Printf("Comment added to ticket %s", humanID(ticketID))
+	if len(photos) > 0 {
+		fmt.Printf(" (%d attachments)", len(photos))
+	}
+	fmt.Println()
+	return nil
+}
+
+type TicketCommentsEditCmd struct {
+	Database string `arg:"" name:"project-id" help:"Project ID"`
+	TicketID string `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
+	Index    int    `arg:"" help:"Comment number (as shown by 'comments list')"`
+	Note     string `arg:"" help:"New comment text"`
+}
+
+func (c *TicketCommentsEditCmd) Run(client *api.Client) error {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.EditComment(database, ticketID, c.Index, sanitizeHTML(c.Note)); err != nil {
+		return fmt.Errorf("editing comment: %w", err)
+	}
+
+	fmt.Printf("Comment #%d on ticket %s updated.\n", c.Index, humanID(ticketID))
+	return nil
+}
+
+type TicketCommentsDeleteCmd struct {
+	Database string `arg:"" name:"project-id" help:"Project ID"`
+	TicketID string `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
+	Index    int    `arg:"" help:"Comment number (as shown by 'comments list')"`
+}
+
+func (c *TicketCommentsDeleteCmd) Run(client *api.Client) error {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteComment(database, ticketID, c.Index); err != nil {
+		return fmt.Errorf("deleting comment: %w", err)
+	}
+
+	fmt.Printf("Comment #%d deleted from ticket %s.\n", c.Index, humanID(ticketID))
+	return nil
+}
+
+type TicketAttachmentsAddCmd struct {
+	Database string   `arg:"" name:"project-id" help:"Project ID"`
+	TicketID string   `arg:"" help:"Ticket ID (human ID or full CouchDB ID)"`
+	Files    []string `arg:"" name:"file" type:"existingfile" help:"Files to upload"`
+}
+
+func (c *TicketAttachmentsAddCmd) Run(client *api.Client) error {
+	database, ticketID, err := resolveTicketID(client, c.Database, c.TicketID)
+	if err != nil {
+		return err
+	}
+
+	return uploadAttachments(client, database, ticketID, c.Files)
+}
+
 // --- Shared attachment helpers ---
 
 var thumbnailPattern = regexp.MustCompile(`\.\d+x\d+\.`)
@@ -825,6 +1041,60 @@ func extractAttachments(doc map[string]interface{}) []attachmentInfo {
 	return result
 }
 
+// loadAttachmentFiles reads files from disk for upload as document attachments.
+// Files are named by timestamp like the mobile app does (e.g. 20260303-112330.jpg);
+// names already used by the document get a suffix when the files are added.
+func loadAttachmentFiles(paths []string) ([]api.AttachmentFile, error) {
+	var files []api.AttachmentFile
+	stamp := time.Now().Format("20060102-150405")
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+
+		name := stamp
+		if len(paths) > 1 {
+			name = fmt.Sprintf("%s-%d", stamp, i+1)
+		}
+		name += strings.ToLower(filepath.Ext(path))
+
+		files = append(files, api.AttachmentFile{
+			Name:        name,
+			ContentType: getContentType(path),
+			Data:        data,
+		})
+	}
+	return files, nil
+}
+
+// uploadAttachments uploads files under their own names and prints what was stored
+func uploadAttachments(client *api.Client, database, docID string, paths []string) error {
+	var files []api.AttachmentFile
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		files = append(files, api.AttachmentFile{
+			Name:        filepath.Base(path),
+			ContentType: getContentType(path),
+			Data:        data,
+		})
+	}
+
+	names, err := client.AddAttachments(database, docID, files)
+	if err != nil {
+		return fmt.Errorf("uploading attachments: %w", err)
+	}
+
+	for i, name := range names {
+		fmt.Printf("Uploaded %s (%s)\n", name, formatFileSize(int64(len(files[i].Data))))
+	}
+	fmt.Printf("\nUploaded %d attachments\n", len(names))
+	return nil
+}
+
 func printAttachmentsTable(attachments []attachmentInfo) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "NAME\tTYPE\tSIZE\tTHUMBNAIL")
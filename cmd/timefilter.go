@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/dutchview/edcontrols-cli/internal/schedule"
 )
 
 // DateFilterSet holds parsed time boundaries for date filtering.
@@ -54,6 +57,33 @@ func (f *DateFilterSet) MatchesDates(createdStr, modifiedStr string) bool {
 	return true
 }
 
+// ParseDateFilters parses the --created-after/before and --modified-after/before flags.
+// Empty values leave the filter unset.
+func ParseDateFilters(createdAfter, createdBefore, modifiedAfter, modifiedBefore string) (DateFilterSet, error) {
+	var filters DateFilterSet
+	flags := []struct {
+		name  string
+		value string
+		dst   **time.Time
+	}{
+		{"--created-after", createdAfter, &filters.CreatedAfter},
+		{"--created-before", createdBefore, &filters.CreatedBefore},
+		{"--modified-after", modifiedAfter, &filters.ModifiedAfter},
+		{"--modified-before", modifiedBefore, &filters.ModifiedBefore},
+	}
+	for _, f := range flags {
+		if f.value == "" {
+			continue
+		}
+		t, err := ParseRelativeTime(f.value)
+		if err != nil {
+			return DateFilterSet{}, fmt.Errorf("%s: %w", f.name, err)
+		}
+		*f.dst = &t
+	}
+	return filters, nil
+}
+
 var relativeTimeRe = regexp.MustCompile(`^(\d+)(d|w|mo|y)$`)
 
 // ParseRelativeTime parses a relative time expression (e.g., "3d", "2w", "1mo", "1y")
@@ -85,6 +115,27 @@ func ParseRelativeTime(s string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("invalid time expression %q (use e.g. 3d, 2w, 1mo, 1y, or 2026-01-15)", s)
 }
 
+// ParseDueDate parses a due date: a time from now with the units of ParseRelativeTime
+// (e.g., "3d", "+2w", "1mo"), a date (e.g., "2026-01-15") or an ISO 8601 timestamp.
+// Relative times and dates are the end of that day. Returns the date as stored in
+// documents.
+func ParseDueDate(s string, now time.Time) (string, error) {
+	s = strings.TrimSpace(s)
+	due, err := schedule.ParseOffset(s, now)
+	if err != nil {
+		if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+			due = t
+		} else if t, err := parseAPIDate(s); err == nil {
+			return t.UTC().Format("2006-01-02T15:04:05.000Z"), nil
+		} else {
+			return "", fmt.Errorf("invalid due date %q (use e.g. 3d, 2w, 1mo, 2026-01-15 or 2026-01-15T12:00:00Z)", s)
+		}
+	}
+
+	due = time.Date(due.Year(), due.Month(), due.Day(), 23, 59, 59, 0, due.Location())
+	return due.UTC().Format("2006-01-02T15:04:05.000Z"), nil
+}
+
 // parseAPIDate parses date strings from the EdControls API.
 // Supports ISO 8601 formats with and without timezone.
 func parseAPIDate(s string) (time.Time, error) {
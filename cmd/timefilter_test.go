@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -224,3 +225,59 @@ func withinSeconds(a, b time.Time, n int) bool {
 	}
 	return diff < time.Duration(n)*time.Second
 }
+
+func TestParseDueDate(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "3d", want: "2026-03-13T23:59:59.000Z"},
+		{input: "+2w", want: "2026-03-24T23:59:59.000Z"},
+		{input: "1mo", want: "2026-04-10T23:59:59.000Z"},
+		{input: "2026-05-01", want: "2026-05-01T23:59:59.000Z"},
+		{input: "2026-05-01T12:00:00Z", want: "2026-05-01T12:00:00.000Z"},
+		{input: "next week", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDueDate(tt.input, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseDueDate(%q) expected error, got %q", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDueDate(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDueDate(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateFilters(t *testing.T) {
+	filters, err := ParseDateFilters("2026-01-15", "", "", "2026-02-01")
+	if err != nil {
+		t.Fatalf("ParseDateFilters error: %v", err)
+	}
+	if filters.CreatedAfter == nil || filters.CreatedAfter.Format("2006-01-02") != "2026-01-15" {
+		t.Errorf("CreatedAfter = %v, want 2026-01-15", filters.CreatedAfter)
+	}
+	if filters.CreatedBefore != nil || filters.ModifiedAfter != nil {
+		t.Errorf("unset filters should be nil, got %+v", filters)
+	}
+	if filters.ModifiedBefore == nil || filters.ModifiedBefore.Format("2006-01-02") != "2026-02-01" {
+		t.Errorf("ModifiedBefore = %v, want 2026-02-01", filters.ModifiedBefore)
+	}
+
+	_, err = ParseDateFilters("", "", "yesterday", "")
+	if err == nil || !strings.HasPrefix(err.Error(), "--modified-after: ") {
+		t.Errorf("expected --modified-after error, got %v", err)
+	}
+}
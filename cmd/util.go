@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -17,6 +18,14 @@ func printJSON(v interface{}) error {
 	return nil
 }
 
+// confirm asks a yes/no question on stdin. Anything but an explicit yes means no.
+func confirm(prompt string) bool {
+	fmt.Printf("%s [y/N]: ", prompt)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -5,4 +5,6 @@ go 1.21
 require (
 	github.com/alecthomas/kong v1.2.1
 	github.com/joho/godotenv v1.5.1
+	github.com/jung-kurt/gofpdf v1.16.2
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,466 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ratingMax is the highest value of a rating question without a max setting
+const ratingMax = 5
+
+// AnswerInput is an answer for one audit question. Category and Question are either
+// the category name / question text or a 1-based position.
+type AnswerInput struct {
+	Category string       `json:"category" yaml:"category"`
+	Question string       `json:"question" yaml:"question"`
+	Values   AnswerValues `json:"value" yaml:"value"`
+	Clear    bool         `json:"clear,omitempty" yaml:"clear,omitempty"`
+}
+
+// AnswerValues holds one or more answer values. In YAML it can be written as a
+// single scalar or as a list.
+type AnswerValues []string
+
+// UnmarshalYAML accepts a scalar or a sequence of scalars
+func (v *AnswerValues) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*v = AnswerValues{node.Value}
+		return nil
+	case yaml.SequenceNode:
+		var values []string
+		if err := node.Decode(&values); err != nil {
+			return err
+		}
+		*v = values
+		return nil
+	}
+	return fmt.Errorf("line %d: value must be a string or a list", node.Line)
+}
+
+// AnswerResult describes an answer that was set on an audit question
+type AnswerResult struct {
+	Category  string        `json:"category"`
+	Question  string        `json:"question"`
+	Property  string        `json:"property"`
+	OldAnswer []interface{} `json:"oldAnswer"`
+	Answer    []interface{} `json:"answer"`
+}
+
+// AnswerAuditQuestions validates and sets answers on an audit, recording an operation.
+// All answers are validated first; if any is invalid nothing is written and the
+// returned error lists every problem.
+func (c *Client) AnswerAuditQuestions(database, auditID string, answers []AnswerInput) ([]AnswerResult, error) {
+	doc, err := c.GetDocument(database, auditID)
+	if err != nil {
+		return nil, fmt.Errorf("getting audit: %w", err)
+	}
+
+	results, err := applyAnswers(doc, answers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.saveAnswers(database, auditID, doc, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// saveAnswers saves an audit document with changed answers and records an operation
+func (c *Client) saveAnswers(database, auditID string, doc map[string]interface{}, results []AnswerResult) error {
+	email, err := c.Email()
+	if err != nil {
+		return fmt.Errorf("getting user email: %w", err)
+	}
+
+	var changedProps []string
+	var oldValues, newValues []interface{}
+	for _, r := range results {
+		changedProps = append(changedProps, r.Property)
+		oldValues = append(oldValues, r.OldAnswer)
+		newValues = append(newValues, r.Answer)
+	}
+
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	markModified(doc, email, now)
+	appendOperation(doc, email, now, changedProps, oldValues, newValues)
+
+	return c.UpdateDocument(database, auditID, doc)
+}
+
+// CheckAuditAnswers validates answers against an audit without saving them and
+// returns the changes that would be made
+func (c *Client) CheckAuditAnswers(database, auditID string, answers []AnswerInput) ([]AnswerResult, error) {
+	doc, err := c.GetDocument(database, auditID)
+	if err != nil {
+		return nil, fmt.Errorf("getting audit: %w", err)
+	}
+	return applyAnswers(doc, answers)
+}
+
+// applyAnswers sets answers on the questions of an audit document
+func applyAnswers(doc map[string]interface{}, answers []AnswerInput) ([]AnswerResult, error) {
+	categories, _ := doc["questions"].([]interface{})
+	if len(categories) == 0 {
+		return nil, fmt.Errorf("audit has no questions")
+	}
+	if len(answers) == 0 {
+		return nil, fmt.Errorf("no answers given")
+	}
+
+	var errs []string
+	var results []AnswerResult
+	for i, input := range answers {
+		prefix := fmt.Sprintf("answer %d (%s / %s)", i+1, input.Category, input.Question)
+
+		ci, qi, question, err := findAuditQuestion(categories, input.Category, input.Question)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", prefix, err))
+			continue
+		}
+
+		var answer []interface{}
+		if input.Clear {
+			answer = []interface{}{}
+		} else {
+			answer, err = parseAnswer(questionSettings(question), input.Values)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", prefix, err))
+				continue
+			}
+		}
+
+		oldAnswer, _ := question["answer"].([]interface{})
+		question["answer"] = answer
+
+		categoryName, _ := categories[ci].(map[string]interface{})["categoryName"].(string)
+		questionText, _ := question["question"].(string)
+		results = append(results, AnswerResult{
+			Category:  categoryName,
+			Question:  questionText,
+			Property:  fmt.Sprintf("questions.%d.questions.%d.answer", ci, qi),
+			OldAnswer: oldAnswer,
+			Answer:    answer,
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid answers:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+	return results, nil
+}
+
+// findAuditQuestion finds a question by category name or position and question text
+// or position. Names are matched case-insensitively; a number is only used as a
+// position when no name matches it.
+func findAuditQuestion(categories []interface{}, category, question string) (int, int, map[string]interface{}, error) {
+	ci, err := findByNameOrIndex(len(categories), category, func(i int) string {
+		cat, _ := categories[i].(map[string]interface{})
+		name, _ := cat["categoryName"].(string)
+		return name
+	})
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("category %w", err)
+	}
+
+	cat, _ := categories[ci].(map[string]interface{})
+	questions, _ := cat["questions"].([]interface{})
+	qi, err := findByNameOrIndex(len(questions), question, func(i int) string {
+		q, _ := questions[i].(map[string]interface{})
+		text, _ := q["question"].(string)
+		return text
+	})
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("question %w", err)
+	}
+
+	q, ok := questions[qi].(map[string]interface{})
+	if !ok {
+		return 0, 0, nil, fmt.Errorf("question %q is malformed", question)
+	}
+	return ci, qi, q, nil
+}
+
+// findByNameOrIndex returns the index of the item whose name matches key, or
+// key as a 1-based position
+func findByNameOrIndex(count int, key string, name func(int) string) (int, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return 0, fmt.Errorf("is required")
+	}
+
+	for i := 0; i < count; i++ {
+		if strings.EqualFold(strings.TrimSpace(name(i)), key) {
+			return i, nil
+		}
+	}
+
+	if n, err := strconv.Atoi(key); err == nil {
+		if n < 1 || n > count {
+			return 0, fmt.Errorf("%d out of range (1-%d)", n, count)
+		}
+		return n - 1, nil
+	}
+	return 0, fmt.Errorf("%q not found", key)
+}
+
+// questionSettings decodes the settings of a raw question
+func questionSettings(question map[string]interface{}) TemplateQuestionSettings {
+	var settings TemplateQuestionSettings
+	if raw, ok := question["settings"]; ok {
+		data, _ := json.Marshal(raw)
+		_ = json.Unmarshal(data, &settings)
+	}
+	return settings
+}
+
+// parseAnswer validates values against a question's answer type and returns the
+// answer in the format stored in the audit document
+func parseAnswer(settings TemplateQuestionSettings, values []string) ([]interface{}, error) {
+	var cleaned []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			cleaned = append(cleaned, v)
+		}
+	}
+	if len(cleaned) == 0 {
+		return nil, fmt.Errorf("value is required")
+	}
+
+	at := settings.AnswerType
+	if at != "multiplechoice" && at != "freetext" && len(cleaned) > 1 {
+		return nil, fmt.Errorf("%s question takes a single value", at)
+	}
+	value := cleaned[0]
+
+	switch at {
+	case "yesnona":
+		if answer, ok := yesNoAnswer(value); ok {
+			return []interface{}{answer}, nil
+		}
+		// Labels from the question styling, e.g. "Not OK" for no
+		if settings.Styling != nil {
+			for key, opt := range settings.Styling.Options {
+				if opt.Label == "" || !strings.EqualFold(opt.Label, value) {
+					continue
+				}
+				if answer, ok := yesNoAnswer(key); ok {
+					return []interface{}{answer}, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("invalid value %q (must be yes, no or na)", value)
+
+	case "freetext":
+		return []interface{}{strings.Join(cleaned, "\n")}, nil
+
+	case "numeric":
+		n, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, fmt.Errorf("invalid number %q", value)
+		}
+		return []interface{}{n}, nil
+
+	case "rating":
+		lo, hi := ratingBounds(settings)
+		n, err := strconv.Atoi(value)
+		if err != nil || n < lo || n > hi {
+			return nil, fmt.Errorf("invalid rating %q (must be %d-%d)", value, lo, hi)
+		}
+		return []interface{}{float64(n)}, nil
+
+	case "date":
+		for _, layout := range []string{"2006-01-02", time.RFC3339, "2006-01-02T15:04:05.000Z"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				return []interface{}{t.Format("2006-01-02")}, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid date %q (use YYYY-MM-DD)", value)
+
+	case "time":
+		for _, layout := range []string{"15:04", "15:04:05"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				return []interface{}{t.Format("15:04")}, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid time %q (use HH:MM)", value)
+
+	case "duration":
+		d, err := parseAnswerDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{d}, nil
+
+	case "multiplechoice":
+		return parseChoices(settings, cleaned)
+
+	case "signature", "statictext":
+		return nil, fmt.Errorf("%s questions can't be answered from the command line", at)
+	}
+
+	return nil, fmt.Errorf("unsupported answer type %q", at)
+}
+
+// parseAnswerDuration accepts HH:MM or a Go duration such as 1h30m and returns HH:MM
+func parseAnswerDuration(value string) (string, error) {
+	var minutes int
+	if h, m, ok := strings.Cut(value, ":"); ok {
+		hours, err1 := strconv.Atoi(h)
+		mins, err2 := strconv.Atoi(m)
+		if err1 != nil || err2 != nil || hours < 0 || mins < 0 || mins > 59 {
+			return "", fmt.Errorf("invalid duration %q (use HH:MM or e.g. 1h30m)", value)
+		}
+		minutes = hours*60 + mins
+	} else {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return "", fmt.Errorf("invalid duration %q (use HH:MM or e.g. 1h30m)", value)
+		}
+		minutes = int(d.Minutes())
+	}
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60), nil
+}
+
+// yesNoAnswer returns the stored value (yes, no or na) of a yes/no answer
+func yesNoAnswer(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "y", "true":
+		return "yes", true
+	case "no", "n", "false":
+		return "no", true
+	case "na", "n/a", "n.a.":
+		return "na", true
+	}
+	return "", false
+}
+
+// parseChoices maps values (option IDs or option texts, several can be separated
+// by ";") to the option IDs of a multiple choice question
+func parseChoices(settings TemplateQuestionSettings, values []string) ([]interface{}, error) {
+	var choices []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ";") {
+			if part = strings.TrimSpace(part); part != "" {
+				choices = append(choices, part)
+			}
+		}
+	}
+	if settings.Choice != "multiple" && len(choices) > 1 {
+		return nil, fmt.Errorf("question allows a single choice, got %d", len(choices))
+	}
+
+	var answer []interface{}
+	seen := make(map[string]bool)
+	for _, v := range choices {
+		id := ""
+		for _, opt := range settings.RichOptions {
+			if opt.ID == v || strings.EqualFold(strings.TrimSpace(opt.Text), v) {
+				id = opt.ID
+				break
+			}
+		}
+		if id == "" {
+			var options []string
+			for _, opt := range settings.RichOptions {
+				options = append(options, fmt.Sprintf("%s (%s)", opt.Text, opt.ID))
+			}
+			return nil, fmt.Errorf("unknown option %q (options: %s)", v, strings.Join(options, ", "))
+		}
+		if !seen[id] {
+			seen[id] = true
+			answer = append(answer, id)
+		}
+	}
+	return answer, nil
+}
+
+// LoadAnswersFile reads answers from a CSV or YAML file, based on the file extension.
+//
+// CSV files need a header row with the columns category, question and value; other
+// columns are ignored. Multiple choice values are separated by ";".
+// YAML files contain a list of {category, question, value} items where value is a
+// string or a list.
+func LoadAnswersFile(path string) ([]AnswerInput, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening answers file: %w", err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return parseAnswersCSV(f)
+	case ".yaml", ".yml":
+		var answers []AnswerInput
+		if err := yaml.NewDecoder(f).Decode(&answers); err != nil {
+			return nil, fmt.Errorf("parsing answers file: %w", err)
+		}
+		return answers, nil
+	}
+	return nil, fmt.Errorf("unsupported answers file %q (use .csv, .yaml or .yml)", path)
+}
+
+// parseAnswersCSV reads answers from CSV with a category,question,value header
+func parseAnswersCSV(r io.Reader) ([]AnswerInput, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing answers file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("answers file is empty")
+	}
+
+	columns := map[string]int{"category": -1, "question": -1, "value": -1}
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; ok {
+			columns[name] = i
+		}
+	}
+	for name, i := range columns {
+		if i < 0 {
+			return nil, fmt.Errorf("answers file is missing the %q column", name)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i := columns[name]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var answers []AnswerInput
+	for _, record := range records[1:] {
+		category, question, value := field(record, "category"), field(record, "question"), field(record, "value")
+		if category == "" && question == "" && value == "" {
+			continue
+		}
+		// Unanswered rows in a spreadsheet are skipped rather than clearing the answer
+		if value == "" {
+			continue
+		}
+		answers = append(answers, AnswerInput{
+			Category: category,
+			Question: question,
+			Values:   AnswerValues{value},
+		})
+	}
+	return answers, nil
+}
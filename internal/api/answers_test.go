@@ -0,0 +1,197 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAnswer(t *testing.T) {
+	choice := TemplateQuestionSettings{
+		AnswerType: "multiplechoice",
+		Choice:     "single",
+		Answer:     []string{"opt1", "opt2"},
+		RichOptions: []RichOption{
+			{ID: "opt1", Text: "Good", Type: "textselect"},
+			{ID: "opt2", Text: "Bad", Type: "textselect"},
+		},
+	}
+	multiple := choice
+	multiple.Choice = "multiple"
+	labelled := TemplateQuestionSettings{AnswerType: "yesnona", Styling: &QuestionStyling{Options: map[string]StylingOption{
+		"YES": {Label: "OK"},
+		"NO":  {Label: "Not OK"},
+	}}}
+
+	tests := []struct {
+		name     string
+		settings TemplateQuestionSettings
+		values   []string
+		want     []interface{}
+		wantErr  bool
+	}{
+		{name: "yes", settings: TemplateQuestionSettings{AnswerType: "yesnona"}, values: []string{"Yes"}, want: []interface{}{"yes"}},
+		{name: "n/a", settings: TemplateQuestionSettings{AnswerType: "yesnona"}, values: []string{"n/a"}, want: []interface{}{"na"}},
+		{name: "styling label", settings: labelled, values: []string{"not ok"}, want: []interface{}{"no"}},
+		{name: "invalid yesnona", settings: TemplateQuestionSettings{AnswerType: "yesnona"}, values: []string{"maybe"}, wantErr: true},
+		{name: "numeric", settings: TemplateQuestionSettings{AnswerType: "numeric"}, values: []string{"3.5"}, want: []interface{}{3.5}},
+		{name: "numeric with comma", settings: TemplateQuestionSettings{AnswerType: "numeric"}, values: []string{"3,5"}, want: []interface{}{3.5}},
+		{name: "invalid numeric", settings: TemplateQuestionSettings{AnswerType: "numeric"}, values: []string{"abc"}, wantErr: true},
+		{name: "rating", settings: TemplateQuestionSettings{AnswerType: "rating"}, values: []string{"4"}, want: []interface{}{4.0}},
+		{name: "rating out of range", settings: TemplateQuestionSettings{AnswerType: "rating"}, values: []string{"6"}, wantErr: true},
+		{name: "rating on a custom scale", settings: TemplateQuestionSettings{AnswerType: "rating", Max: floatPtr(10)}, values: []string{"8"}, want: []interface{}{8.0}},
+		{name: "date", settings: TemplateQuestionSettings{AnswerType: "date"}, values: []string{"2026-03-15"}, want: []interface{}{"2026-03-15"}},
+		{name: "invalid date", settings: TemplateQuestionSettings{AnswerType: "date"}, values: []string{"15-03-2026"}, wantErr: true},
+		{name: "time", settings: TemplateQuestionSettings{AnswerType: "time"}, values: []string{"9:30"}, want: []interface{}{"09:30"}},
+		{name: "invalid time", settings: TemplateQuestionSettings{AnswerType: "time"}, values: []string{"25:00"}, wantErr: true},
+		{name: "time padded", settings: TemplateQuestionSettings{AnswerType: "time"}, values: []string{"09:30:00"}, want: []interface{}{"09:30"}},
+		{name: "duration go", settings: TemplateQuestionSettings{AnswerType: "duration"}, values: []string{"1h30m"}, want: []interface{}{"01:30"}},
+		{name: "duration clock", settings: TemplateQuestionSettings{AnswerType: "duration"}, values: []string{"2:05"}, want: []interface{}{"02:05"}},
+		{name: "choice by text", settings: choice, values: []string{"good"}, want: []interface{}{"opt1"}},
+		{name: "choice by id", settings: choice, values: []string{"opt2"}, want: []interface{}{"opt2"}},
+		{name: "single choice rejects two", settings: choice, values: []string{"opt1;opt2"}, wantErr: true},
+		{name: "multiple choice", settings: multiple, values: []string{"Good; Bad", "opt1"}, want: []interface{}{"opt1", "opt2"}},
+		{name: "unknown option", settings: choice, values: []string{"Ugly"}, wantErr: true},
+		{name: "signature", settings: TemplateQuestionSettings{AnswerType: "signature"}, values: []string{"x"}, wantErr: true},
+		{name: "empty", settings: TemplateQuestionSettings{AnswerType: "freetext"}, values: []string{" "}, wantErr: true},
+		{name: "two values for numeric", settings: TemplateQuestionSettings{AnswerType: "numeric"}, values: []string{"1", "2"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAnswer(tt.settings, tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAnswer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAnswer() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func auditDoc() map[string]interface{} {
+	return map[string]interface{}{
+		"questions": []interface{}{
+			map[string]interface{}{
+				"categoryName": "Fire safety",
+				"questions": []interface{}{
+					map[string]interface{}{"question": "Extinguisher present?", "answer": []interface{}{}, "settings": map[string]interface{}{"answertype": "yesnona"}},
+					map[string]interface{}{"question": "Number of exits", "answer": []interface{}{}, "settings": map[string]interface{}{"answertype": "numeric"}},
+					map[string]interface{}{"question": "Exit sign condition", "answer": []interface{}{"yes"}, "settings": map[string]interface{}{"answertype": "yesnona"}},
+				},
+			},
+			map[string]interface{}{
+				"categoryName": "2",
+				"questions": []interface{}{
+					map[string]interface{}{"question": "Remarks", "settings": map[string]interface{}{"answertype": "freetext"}},
+				},
+			},
+		},
+	}
+}
+
+func TestApplyAnswers(t *testing.T) {
+	doc := auditDoc()
+	results, err := applyAnswers(doc, []AnswerInput{
+		{Category: "fire safety", Question: "2", Values: AnswerValues{"3"}},
+		{Category: "Fire safety", Question: "exit sign condition", Clear: true},
+		{Category: "2", Question: "1", Values: AnswerValues{"All fine"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	if results[0].Property != "questions.0.questions.1.answer" || !reflect.DeepEqual(results[0].Answer, []interface{}{3.0}) {
+		t.Errorf("result 0 = %+v", results[0])
+	}
+	if !reflect.DeepEqual(results[1].OldAnswer, []interface{}{"yes"}) || len(results[1].Answer) != 0 {
+		t.Errorf("result 1 = %+v", results[1])
+	}
+	// A category named "2" wins over the second category by position
+	if results[2].Property != "questions.1.questions.0.answer" {
+		t.Errorf("result 2 property = %s", results[2].Property)
+	}
+}
+
+func TestApplyAnswersCollectsErrors(t *testing.T) {
+	_, err := applyAnswers(auditDoc(), []AnswerInput{
+		{Category: "Electrical", Question: "1", Values: AnswerValues{"yes"}},
+		{Category: "Fire safety", Question: "9", Values: AnswerValues{"yes"}},
+		{Category: "Fire safety", Question: "1", Values: AnswerValues{"maybe"}},
+		{Category: "Fire safety", Question: "2", Values: AnswerValues{"4"}},
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"answer 1", "answer 2", "answer 3"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s: %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "answer 4") {
+		t.Errorf("valid answer reported as an error: %v", err)
+	}
+}
+
+func TestLoadAnswersFile(t *testing.T) {
+	dir := t.TempDir()
+
+	csvPath := filepath.Join(dir, "answers.csv")
+	csvData := "\ufeffCategory,Question,Question text,Value\n" +
+		"Fire safety,1,Extinguisher present?,yes\n" +
+		"Fire safety,2,Number of exits,\n" +
+		"Checks,3,Findings,\"opt1;opt2\"\n"
+	if err := os.WriteFile(csvPath, []byte(csvData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadAnswersFile(csvPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []AnswerInput{
+		{Category: "Fire safety", Question: "1", Values: AnswerValues{"yes"}},
+		{Category: "Checks", Question: "3", Values: AnswerValues{"opt1;opt2"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CSV answers = %+v, want %+v", got, want)
+	}
+
+	yamlPath := filepath.Join(dir, "answers.yaml")
+	yamlData := `- category: Fire safety
+  question: 1
+  value: yes
+- category: Checks
+  question: Findings
+  value: [opt1, opt2]
+`
+	if err := os.WriteFile(yamlPath, []byte(yamlData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = LoadAnswersFile(yamlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []AnswerInput{
+		{Category: "Fire safety", Question: "1", Values: AnswerValues{"yes"}},
+		{Category: "Checks", Question: "Findings", Values: AnswerValues{"opt1", "opt2"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("YAML answers = %+v, want %+v", got, want)
+	}
+
+	missing := filepath.Join(dir, "bad.csv")
+	if err := os.WriteFile(missing, []byte("category,value\nA,yes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAnswersFile(missing); err == nil {
+		t.Error("expected an error for a CSV without a question column")
+	}
+}
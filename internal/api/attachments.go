@@ -0,0 +1,221 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// thumbnailMaxSize is the maximum width and height of generated thumbnails
+const thumbnailMaxSize = 256
+
+// AddAttachments uploads files as attachments of a ticket or audit and records an
+// operation. Thumbnails are generated for JPEG, PNG and GIF images. Files whose name
+// is already taken get a numeric suffix. Returns the names the files were stored under.
+func (c *Client) AddAttachments(database, docID string, files []AttachmentFile) ([]string, error) {
+	doc, err := c.GetDocument(database, docID)
+	if err != nil {
+		return nil, fmt.Errorf("getting document: %w", err)
+	}
+
+	email, err := c.Email()
+	if err != nil {
+		return nil, fmt.Errorf("getting user email: %w", err)
+	}
+
+	names := reserveAttachmentNames(doc, files)
+
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+
+	addInlineAttachments(doc, files)
+	markModified(doc, email, now)
+	appendOperation(doc, email, now, []string{"attachments"}, []interface{}{nil}, []interface{}{names})
+
+	if err := c.UpdateDocument(database, docID, doc); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// reserveAttachmentNames renames files whose name, or the name of their thumbnail, is
+// already used by an attachment of the document (or by an earlier file), see
+// uniqueAttachmentName. Returns the names.
+func reserveAttachmentNames(doc map[string]interface{}, files []AttachmentFile) []string {
+	existing, _ := doc["_attachments"].(map[string]interface{})
+	taken := make(map[string]bool, len(existing))
+	for name := range existing {
+		taken[name] = true
+	}
+
+	names := make([]string, 0, len(files))
+	for i := range files {
+		width, height, hasThumb := thumbnailDimensions(files[i])
+		isTaken := func(name string) bool {
+			return taken[name] || hasThumb && taken[thumbnailName(name, width, height)]
+		}
+		files[i].Name = uniqueAttachmentName(files[i].Name, isTaken)
+		taken[files[i].Name] = true
+		if hasThumb {
+			taken[thumbnailName(files[i].Name, width, height)] = true
+		}
+		names = append(names, files[i].Name)
+	}
+	return names
+}
+
+// uniqueAttachmentName returns name, or name with a "-N" suffix before the extension
+// if it is already taken
+func uniqueAttachmentName(name string, taken func(string) bool) string {
+	if !taken(name) {
+		return name
+	}
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d%s", base, i, ext)
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+}
+
+// removeAttachment deletes an attachment and its thumbnails from an _attachments map
+func removeAttachment(attachments map[string]interface{}, name string) {
+	delete(attachments, name)
+	ext := path.Ext(name)
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(strings.TrimSuffix(name, ext)) + `\.\d+x\d+` + regexp.QuoteMeta(ext) + `$`)
+	for key := range attachments {
+		if pattern.MatchString(key) {
+			delete(attachments, key)
+		}
+	}
+}
+
+// thumbnailName returns the attachment name of a thumbnail: <base>.<w>x<h>.<ext>
+func thumbnailName(name string, width, height int) string {
+	ext := path.Ext(name)
+	return fmt.Sprintf("%s.%dx%d%s", strings.TrimSuffix(name, ext), width, height, ext)
+}
+
+// thumbnailSize scales width and height down to fit within limit, keeping the aspect ratio
+func thumbnailSize(width, height, limit int) (int, int) {
+	if width <= limit && height <= limit {
+		return width, height
+	}
+	if width >= height {
+		h := height * limit / width
+		if h < 1 {
+			h = 1
+		}
+		return limit, h
+	}
+	w := width * limit / height
+	if w < 1 {
+		w = 1
+	}
+	return w, limit
+}
+
+// thumbnailDimensions returns the size of the thumbnail makeThumbnail creates for f.
+// Returns false for files that are not JPEG, PNG or GIF images, can't be decoded or
+// already fit within the thumbnail size.
+func thumbnailDimensions(f AttachmentFile) (int, int, bool) {
+	switch f.ContentType {
+	case "image/jpeg", "image/png", "image/gif":
+	default:
+		return 0, 0, false
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(f.Data))
+	if err != nil || cfg.Width == 0 || cfg.Height == 0 {
+		return 0, 0, false
+	}
+	if cfg.Width <= thumbnailMaxSize && cfg.Height <= thumbnailMaxSize {
+		return 0, 0, false
+	}
+	width, height := thumbnailSize(cfg.Width, cfg.Height, thumbnailMaxSize)
+	return width, height, true
+}
+
+// makeThumbnail creates a thumbnail for an image attachment.
+// Returns false for files that get no thumbnail, see thumbnailDimensions.
+func makeThumbnail(f AttachmentFile) (AttachmentFile, bool) {
+	width, height, ok := thumbnailDimensions(f)
+	if !ok {
+		return AttachmentFile{}, false
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(f.Data))
+	if err != nil {
+		return AttachmentFile{}, false
+	}
+	dst := scaleImage(src, width, height)
+
+	var buf bytes.Buffer
+	switch f.ContentType {
+	case "image/jpeg":
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80})
+	case "image/png":
+		err = png.Encode(&buf, dst)
+	case "image/gif":
+		err = gif.Encode(&buf, dst, nil)
+	}
+	if err != nil {
+		return AttachmentFile{}, false
+	}
+
+	return AttachmentFile{
+		Name:        thumbnailName(f.Name, width, height),
+		ContentType: f.ContentType,
+		Data:        buf.Bytes(),
+	}, true
+}
+
+// scaleImage downscales an image by averaging the source pixels covered by each target pixel
+func scaleImage(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
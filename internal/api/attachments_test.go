@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestThumbnailSize(t *testing.T) {
+	tests := []struct {
+		width, height int
+		wantW, wantH  int
+	}{
+		{4000, 3000, 256, 192},
+		{3000, 4000, 192, 256},
+		{100, 50, 100, 50},
+		{10000, 10, 256, 1},
+	}
+
+	for _, tt := range tests {
+		w, h := thumbnailSize(tt.width, tt.height, 256)
+		if w != tt.wantW || h != tt.wantH {
+			t.Errorf("thumbnailSize(%d, %d) = %dx%d, want %dx%d", tt.width, tt.height, w, h, tt.wantW, tt.wantH)
+		}
+	}
+}
+
+func TestMakeThumbnail(t *testing.T) {
+	thumb, ok := makeThumbnail(AttachmentFile{Name: "drone.png", ContentType: "image/png", Data: encodePNG(t, 1024, 512)})
+	if !ok {
+		t.Fatal("expected a thumbnail")
+	}
+	if thumb.Name != "drone.256x128.png" {
+		t.Errorf("Name = %q, want drone.256x128.png", thumb.Name)
+	}
+	// The name must be recognised as a thumbnail by the attachment listing
+	if !regexp.MustCompile(`\.\d+x\d+\.`).MatchString(thumb.Name) {
+		t.Errorf("thumbnail name %q doesn't match the thumbnail pattern", thumb.Name)
+	}
+
+	img, err := png.Decode(bytes.NewReader(thumb.Data))
+	if err != nil {
+		t.Fatalf("decoding thumbnail: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 256 || b.Dy() != 128 {
+		t.Errorf("thumbnail is %dx%d, want 256x128", b.Dx(), b.Dy())
+	}
+
+	small := encodePNG(t, 200, 100)
+	if _, ok := makeThumbnail(AttachmentFile{Name: "icon.png", ContentType: "image/png", Data: small}); ok {
+		t.Error("expected no thumbnail for an image within the thumbnail size")
+	}
+	if _, ok := makeThumbnail(AttachmentFile{Name: "report.pdf", ContentType: "application/pdf", Data: []byte("%PDF")}); ok {
+		t.Error("expected no thumbnail for a PDF")
+	}
+	if _, ok := makeThumbnail(AttachmentFile{Name: "broken.jpg", ContentType: "image/jpeg", Data: []byte("not an image")}); ok {
+		t.Error("expected no thumbnail for invalid image data")
+	}
+}
+
+func TestRemoveAttachment(t *testing.T) {
+	attachments := map[string]interface{}{
+		"photo.jpg":         nil,
+		"photo.256x192.jpg": nil,
+		"photo-1.jpg":       nil,
+		"other.jpg":         nil,
+	}
+
+	removeAttachment(attachments, "photo.jpg")
+
+	if len(attachments) != 2 {
+		t.Errorf("got %d attachments left, want 2: %v", len(attachments), attachments)
+	}
+	for _, name := range []string{"photo-1.jpg", "other.jpg"} {
+		if _, ok := attachments[name]; !ok {
+			t.Errorf("%s was removed", name)
+		}
+	}
+}
+
+func TestUniqueAttachmentName(t *testing.T) {
+	taken := map[string]bool{"photo.jpg": true, "photo-1.jpg": true}
+	isTaken := func(name string) bool { return taken[name] }
+
+	if got := uniqueAttachmentName("new.jpg", isTaken); got != "new.jpg" {
+		t.Errorf("got %q, want new.jpg", got)
+	}
+	if got := uniqueAttachmentName("photo.jpg", isTaken); got != "photo-2.jpg" {
+		t.Errorf("got %q, want photo-2.jpg", got)
+	}
+}
+
+func TestReserveAttachmentNames(t *testing.T) {
+	doc := map[string]interface{}{
+		"_attachments": map[string]interface{}{"20260303-112330.jpg": map[string]interface{}{}},
+	}
+	files := []AttachmentFile{{Name: "20260303-112330.jpg"}, {Name: "plan.pdf"}, {Name: "plan.pdf"}}
+
+	names := reserveAttachmentNames(doc, files)
+	want := []string{"20260303-112330-1.jpg", "plan.pdf", "plan-1.pdf"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+	for i, f := range files {
+		if f.Name != want[i] {
+			t.Errorf("file %d renamed to %q, want %q", i, f.Name, want[i])
+		}
+	}
+}
+
+func TestReserveAttachmentNamesThumbnails(t *testing.T) {
+	// An existing attachment uses the name the thumbnail of drone.png would get
+	doc := map[string]interface{}{
+		"_attachments": map[string]interface{}{"drone.256x128.png": map[string]interface{}{}},
+	}
+	files := []AttachmentFile{
+		{Name: "drone.png", ContentType: "image/png", Data: encodePNG(t, 1024, 512)},
+		{Name: "drone-1.256x128.png", ContentType: "image/png", Data: encodePNG(t, 10, 10)},
+	}
+
+	names := reserveAttachmentNames(doc, files)
+	want := []string{"drone-1.png", "drone-1.256x128-1.png"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+// encodePNG returns an empty PNG image of the given size
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
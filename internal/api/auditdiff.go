@@ -0,0 +1,134 @@
+package api
+
+// Kinds of answer changes between two audits
+const (
+	ChangeFixed     = "fixed"     // A finding in the first audit that isn't one in the second
+	ChangeFailed    = "failed"    // A new finding in the second audit
+	ChangeChanged   = "changed"   // Another change of the answer
+	ChangeAdded     = "added"     // Question only in the second audit
+	ChangeRemoved   = "removed"   // Question only in the first audit
+	ChangeUnchanged = "unchanged" // Same answer
+)
+
+// AnswerChange compares the answers to one question in two audits
+type AnswerChange struct {
+	Category  string `json:"category"`
+	Question  string `json:"question"`
+	Change    string `json:"change"`
+	OldAnswer string `json:"oldAnswer"`
+	NewAnswer string `json:"newAnswer"`
+}
+
+// AuditDiff is the comparison of two audits, usually of the same template
+type AuditDiff struct {
+	Changes []AnswerChange `json:"changes"`
+	Counts  map[string]int `json:"counts"` // Number of changes per kind
+	Before  CategoryScore  `json:"before"`
+	After   CategoryScore  `json:"after"`
+}
+
+// diffQuestion is a question of an audit with its position for alignment
+type diffQuestion struct {
+	category      string
+	question      Question
+	categoryIndex int
+	questionIndex int
+}
+
+// DiffAudits compares the answers of two audits. Questions are aligned by category
+// name and question text (case-insensitive); repeated questions are aligned in
+// order. Answers are findings as in FindAuditFindings.
+func DiffAudits(before, after *Audit, ratingThreshold float64) AuditDiff {
+	diff := AuditDiff{
+		Changes: []AnswerChange{},
+		Counts:  map[string]int{},
+		Before:  ScoreAudit(before).Total,
+		After:   ScoreAudit(after).Total,
+	}
+
+	old := make(map[string][]diffQuestion)
+	var oldKeys []string
+	for _, q := range auditQuestions(before) {
+		key := diffKey(q)
+		if _, ok := old[key]; !ok {
+			oldKeys = append(oldKeys, key)
+		}
+		old[key] = append(old[key], q)
+	}
+
+	add := func(c AnswerChange) {
+		diff.Changes = append(diff.Changes, c)
+		diff.Counts[c.Change]++
+	}
+
+	for _, q := range auditQuestions(after) {
+		key := diffKey(q)
+		newAnswer := AnswerText(q.question.Settings, q.question.Answer)
+		if len(old[key]) == 0 {
+			add(AnswerChange{Category: q.category, Question: q.question.Question, Change: ChangeAdded, NewAnswer: newAnswer})
+			continue
+		}
+		o := old[key][0]
+		old[key] = old[key][1:]
+
+		oldAnswer := AnswerText(o.question.Settings, o.question.Answer)
+		add(AnswerChange{
+			Category:  q.category,
+			Question:  q.question.Question,
+			Change:    answerChange(o.question, q.question, oldAnswer, newAnswer, ratingThreshold),
+			OldAnswer: oldAnswer,
+			NewAnswer: newAnswer,
+		})
+	}
+
+	for _, key := range oldKeys {
+		for _, o := range old[key] {
+			add(AnswerChange{Category: o.category, Question: o.question.Question, Change: ChangeRemoved,
+				OldAnswer: AnswerText(o.question.Settings, o.question.Answer)})
+		}
+	}
+
+	return diff
+}
+
+// answerChange classifies the change between two answers to the same question
+func answerChange(old, new Question, oldAnswer, newAnswer string, ratingThreshold float64) string {
+	wasFinding := isFinding(questionAnswerType(old), old.Answer, ratingThreshold)
+	isNowFinding := isFinding(questionAnswerType(new), new.Answer, ratingThreshold)
+	switch {
+	case wasFinding && !isNowFinding:
+		return ChangeFixed
+	case !wasFinding && isNowFinding:
+		return ChangeFailed
+	case oldAnswer != newAnswer:
+		return ChangeChanged
+	}
+	return ChangeUnchanged
+}
+
+// auditQuestions returns the answerable questions of an audit in order
+func auditQuestions(audit *Audit) []diffQuestion {
+	var questions []diffQuestion
+	for ci, category := range audit.Questions {
+		for qi, q := range category.Questions {
+			if questionAnswerType(q) == "statictext" {
+				continue
+			}
+			questions = append(questions, diffQuestion{category: category.CategoryName, question: q, categoryIndex: ci, questionIndex: qi})
+		}
+	}
+	return questions
+}
+
+// diffKey is the key questions are matched by: the category and question text,
+// ignoring case and HTML formatting
+func diffKey(q diffQuestion) string {
+	return textKey(q.category) + "\x00" + textKey(q.question.Question)
+}
+
+func questionAnswerType(q Question) string {
+	if q.Settings == nil {
+		return ""
+	}
+	return q.Settings.AnswerType
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffAudits(t *testing.T) {
+	yesno := &QuestionSettings{AnswerType: "yesnona"}
+	rating := &QuestionSettings{AnswerType: "rating"}
+	q := func(text string, settings *QuestionSettings, answer ...interface{}) Question {
+		return Question{Question: text, Settings: settings, Answer: answer}
+	}
+
+	before := &Audit{Questions: []QuestionCategory{
+		{CategoryName: "Fire safety", Questions: []Question{
+			q("Extinguisher present?", yesno, "no"),
+			q("Exits free?", yesno, "yes"),
+			q("Alarm tested?", yesno, "yes"),
+			q("Read the instructions", &QuestionSettings{AnswerType: "statictext"}),
+		}},
+		{CategoryName: "Housekeeping", Questions: []Question{
+			q("Tidiness", rating, 2.0),
+			q("Waste bins", yesno, "yes"),
+		}},
+	}}
+	after := &Audit{Questions: []QuestionCategory{
+		{CategoryName: "fire safety", Questions: []Question{
+			q("Extinguisher present? ", yesno, "yes"),
+			q("Exits free?", yesno, "no"),
+			q("Alarm tested?", yesno, "yes"),
+			q("Sprinklers checked?", yesno, "na"),
+		}},
+		{CategoryName: "Housekeeping", Questions: []Question{
+			q("Tidiness", rating, 1.0),
+		}},
+	}}
+
+	diff := DiffAudits(before, after, DefaultRatingThreshold)
+
+	var got []string
+	for _, c := range diff.Changes {
+		got = append(got, c.Question+": "+c.Change)
+	}
+	want := []string{
+		"Extinguisher present? : fixed",
+		"Exits free?: failed",
+		"Alarm tested?: unchanged",
+		"Sprinklers checked?: added",
+		"Tidiness: changed",
+		"Waste bins: removed",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changes =\n%v\nwant\n%v", got, want)
+	}
+
+	wantCounts := map[string]int{ChangeFixed: 1, ChangeFailed: 1, ChangeUnchanged: 1, ChangeAdded: 1, ChangeChanged: 1, ChangeRemoved: 1}
+	if !reflect.DeepEqual(diff.Counts, wantCounts) {
+		t.Errorf("counts = %v", diff.Counts)
+	}
+	if diff.Changes[0].OldAnswer != "No" || diff.Changes[0].NewAnswer != "Yes" {
+		t.Errorf("answers = %q -> %q", diff.Changes[0].OldAnswer, diff.Changes[0].NewAnswer)
+	}
+}
+
+func TestDiffAuditsRepeatedQuestions(t *testing.T) {
+	yesno := &QuestionSettings{AnswerType: "yesnona"}
+	audit := func(answers ...string) *Audit {
+		var questions []Question
+		for _, a := range answers {
+			questions = append(questions, Question{Question: "Floor checked?", Settings: yesno, Answer: []interface{}{a}})
+		}
+		return &Audit{Questions: []QuestionCategory{{CategoryName: "Floors", Questions: questions}}}
+	}
+
+	diff := DiffAudits(audit("yes", "no", "yes"), audit("yes", "yes"), DefaultRatingThreshold)
+	var got []string
+	for _, c := range diff.Changes {
+		got = append(got, c.Change)
+	}
+	if want := []string{ChangeUnchanged, ChangeFixed, ChangeRemoved}; !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+}
+
+func TestDiffAuditsHTMLTexts(t *testing.T) {
+	yesno := &QuestionSettings{AnswerType: "yesnona"}
+	before := &Audit{Questions: []QuestionCategory{{CategoryName: "<b>Doors</b>", Questions: []Question{
+		{Question: "<p>Door closed?</p>", Settings: yesno, Answer: []interface{}{"no"}},
+	}}}}
+	after := &Audit{Questions: []QuestionCategory{{CategoryName: "Doors", Questions: []Question{
+		{Question: "Door closed?", Settings: yesno, Answer: []interface{}{"yes"}},
+	}}}}
+
+	diff := DiffAudits(before, after, DefaultRatingThreshold)
+	if len(diff.Changes) != 1 || diff.Changes[0].Change != ChangeFixed {
+		t.Errorf("changes = %+v, want one fixed answer", diff.Changes)
+	}
+}
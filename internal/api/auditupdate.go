@@ -0,0 +1,183 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// UpdateAuditFieldsOptions contains the audit fields to change. Nil and empty
+// fields are left as they are.
+type UpdateAuditFieldsOptions struct {
+	Name             *string
+	Tags             []string    // Replaces the tags
+	Maps             []string    // Replaces the map IDs
+	Questions        interface{} // Replaces the questions
+	Responsible      *string     // Email of the responsible person
+	ClearResponsible bool
+	DueDate          *string // ISO 8601 timestamp
+	ClearDue         bool
+	Participants     ParticipantsEdit
+	Comment          *string // Add a comment to the audit
+}
+
+// IsEmpty returns true if the options don't change anything
+func (o UpdateAuditFieldsOptions) IsEmpty() bool {
+	return o.Name == nil && o.Tags == nil && o.Maps == nil && o.Questions == nil &&
+		o.Responsible == nil && !o.ClearResponsible && o.DueDate == nil && !o.ClearDue &&
+		o.Participants.IsEmpty() && (o.Comment == nil || *o.Comment == "")
+}
+
+// UpdateAuditFields changes the name, tags, maps, questions, responsible person, due
+// date, participants and comments of an audit in a single write, and records the
+// changes in one operation. Returns the changed properties.
+func (c *Client) UpdateAuditFields(database, auditID string, opts UpdateAuditFieldsOptions) ([]string, error) {
+	doc, err := c.GetDocument(database, auditID)
+	if err != nil {
+		return nil, fmt.Errorf("getting audit: %w", err)
+	}
+
+	email, err := c.Email()
+	if err != nil {
+		return nil, fmt.Errorf("getting user email: %w", err)
+	}
+
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	changedProps, oldValues, newValues, err := applyAuditFields(doc, email, now, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(changedProps) == 0 {
+		return nil, nil
+	}
+
+	markModified(doc, email, now)
+	appendOperation(doc, email, now, changedProps, oldValues, newValues)
+
+	if err := c.UpdateDocument(database, auditID, doc); err != nil {
+		return nil, err
+	}
+	return changedProps, nil
+}
+
+// ApplyAuditFields applies the options to an audit document without saving it, e.g. to
+// check what an update would change. Comments are not supported. Returns the changed
+// properties.
+func ApplyAuditFields(doc map[string]interface{}, opts UpdateAuditFieldsOptions) ([]string, error) {
+	if opts.Comment != nil {
+		return nil, fmt.Errorf("comments can't be applied without saving")
+	}
+	changedProps, _, _, err := applyAuditFields(doc, "", "", opts)
+	return changedProps, err
+}
+
+// applyAuditFields applies the options to an audit document and returns the
+// changed properties with their old and new values
+func applyAuditFields(doc map[string]interface{}, email, now string, opts UpdateAuditFieldsOptions) ([]string, []interface{}, []interface{}, error) {
+	if opts.Responsible != nil && opts.ClearResponsible {
+		return nil, nil, nil, fmt.Errorf("cannot set and clear the responsible person at the same time")
+	}
+	if opts.DueDate != nil && opts.ClearDue {
+		return nil, nil, nil, fmt.Errorf("cannot set and clear the due date at the same time")
+	}
+
+	var changedProps []string
+	var oldValues, newValues []interface{}
+	change := func(prop string, old, new interface{}) {
+		changedProps = append(changedProps, prop)
+		oldValues = append(oldValues, old)
+		newValues = append(newValues, new)
+	}
+
+	if opts.Name != nil {
+		old, _ := doc["name"].(string)
+		if *opts.Name != old {
+			doc["name"] = *opts.Name
+			change("name", old, *opts.Name)
+		}
+	}
+
+	if opts.Tags != nil {
+		old := documentTags(doc)
+		if !equalStrings(old, opts.Tags) {
+			doc["tags"] = opts.Tags
+			change("tags", old, opts.Tags)
+		}
+	}
+
+	if opts.Maps != nil {
+		old := documentStrings(doc, "maps")
+		if !equalStrings(old, opts.Maps) {
+			doc["maps"] = opts.Maps
+			change("maps", old, opts.Maps)
+		}
+	}
+
+	if opts.Questions != nil {
+		// The questions are too large for the operation log; only record that they changed
+		doc["questions"] = opts.Questions
+		change("questions", nil, nil)
+	}
+
+	if opts.Responsible != nil || opts.ClearResponsible {
+		old := documentResponsible(doc)
+		responsible := ""
+		if opts.Responsible != nil {
+			responsible = *opts.Responsible
+		}
+		if responsible != old {
+			setDocumentResponsible(doc, responsible)
+			change("responsible", old, responsible)
+		}
+	}
+
+	if opts.DueDate != nil || opts.ClearDue {
+		dates, _ := doc["dates"].(map[string]interface{})
+		old, _ := dates["dueDate"].(string)
+		due := ""
+		if opts.DueDate != nil {
+			due = *opts.DueDate
+		}
+		if due != old {
+			setDocumentDate(doc, "dueDate", due)
+			change("dueDate", old, due)
+		}
+	}
+
+	if !opts.Participants.IsEmpty() {
+		props, olds, news := applyParticipantsEdit(doc, opts.Participants)
+		changedProps = append(changedProps, props...)
+		oldValues = append(oldValues, olds...)
+		newValues = append(newValues, news...)
+	}
+
+	if opts.Comment != nil && *opts.Comment != "" {
+		comment := map[string]interface{}{
+			"attachments":  []interface{}{},
+			"author":       email,
+			"time":         now,
+			"note":         *opts.Comment,
+			"public":       true,
+			"allowedUsers": "",
+		}
+		if comments, ok := doc["comments"].([]interface{}); ok {
+			doc["comments"] = append(comments, comment)
+		} else {
+			doc["comments"] = []interface{}{comment}
+		}
+		change("comment", nil, *opts.Comment)
+	}
+
+	return changedProps, oldValues, newValues, nil
+}
+
+// documentStrings returns a list of strings stored under key
+func documentStrings(doc map[string]interface{}, key string) []string {
+	values := []string{}
+	list, _ := doc[key].([]interface{})
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
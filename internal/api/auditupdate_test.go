@@ -0,0 +1,112 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyAuditFields(t *testing.T) {
+	doc := map[string]interface{}{
+		"dates": map[string]interface{}{"dueDate": "2026-03-01T23:59:59.000Z"},
+		"participants": map[string]interface{}{
+			"responsible": map[string]interface{}{"email": "old@example.com"},
+			"informed":    []interface{}{map[string]interface{}{"email": "a@example.com"}},
+		},
+	}
+	responsible := "new@example.com"
+	due := "2026-04-01T23:59:59.000Z"
+	comment := "Rescheduled"
+
+	props, olds, news, err := applyAuditFields(doc, "me@example.com", "2026-03-01T10:00:00.000Z", UpdateAuditFieldsOptions{
+		Responsible:  &responsible,
+		DueDate:      &due,
+		Participants: ParticipantsEdit{Inform: []string{"b@example.com"}, Remove: []string{"a@example.com"}},
+		Comment:      &comment,
+	})
+	if err != nil {
+		t.Fatalf("applyAuditFields: %v", err)
+	}
+
+	if want := []string{"responsible", "dueDate", "participants.informed", "comment"}; !reflect.DeepEqual(props, want) {
+		t.Errorf("props = %v, want %v", props, want)
+	}
+	if olds[0] != "old@example.com" || news[0] != responsible {
+		t.Errorf("responsible change = %v -> %v", olds[0], news[0])
+	}
+	if got := documentResponsible(doc); got != responsible {
+		t.Errorf("responsible = %q", got)
+	}
+	if got := doc["dates"].(map[string]interface{})["dueDate"]; got != due {
+		t.Errorf("dueDate = %v", got)
+	}
+	if got := documentParticipants(doc).Informed; len(got) != 1 || got[0].Email != "b@example.com" {
+		t.Errorf("informed = %v", got)
+	}
+	if comments := doc["comments"].([]interface{}); len(comments) != 1 {
+		t.Errorf("comments = %v", comments)
+	}
+}
+
+func TestApplyAuditFieldsDocument(t *testing.T) {
+	doc := map[string]interface{}{
+		"name": "Safety walk",
+		"tags": []interface{}{"safety"},
+		"maps": []interface{}{"map1"},
+	}
+	name := "Safety walk week 12"
+	questions := []interface{}{map[string]interface{}{"categoryName": "General"}}
+
+	props, olds, news, err := applyAuditFields(doc, "me@example.com", "now", UpdateAuditFieldsOptions{
+		Name:      &name,
+		Tags:      []string{"safety"},
+		Maps:      []string{"map1", "map2"},
+		Questions: questions,
+	})
+	if err != nil {
+		t.Fatalf("applyAuditFields: %v", err)
+	}
+
+	if want := []string{"name", "maps", "questions"}; !reflect.DeepEqual(props, want) {
+		t.Errorf("props = %v, want %v", props, want)
+	}
+	if olds[0] != "Safety walk" || news[0] != name || doc["name"] != name {
+		t.Errorf("name change = %v -> %v, name = %v", olds[0], news[0], doc["name"])
+	}
+	if !reflect.DeepEqual(olds[1], []string{"map1"}) || !reflect.DeepEqual(doc["maps"], []string{"map1", "map2"}) {
+		t.Errorf("maps change from %v, maps = %v", olds[1], doc["maps"])
+	}
+	if !reflect.DeepEqual(doc["questions"], questions) {
+		t.Errorf("questions = %v", doc["questions"])
+	}
+}
+
+func TestApplyAuditFieldsUnchanged(t *testing.T) {
+	doc := map[string]interface{}{
+		"dates": map[string]interface{}{"dueDate": "2026-03-01T23:59:59.000Z"},
+	}
+	due := "2026-03-01T23:59:59.000Z"
+
+	props, _, _, err := applyAuditFields(doc, "me@example.com", "now", UpdateAuditFieldsOptions{DueDate: &due, ClearResponsible: true})
+	if err != nil {
+		t.Fatalf("applyAuditFields: %v", err)
+	}
+	if len(props) != 0 {
+		t.Errorf("props = %v, want none", props)
+	}
+	if _, ok := doc["participants"]; ok {
+		t.Error("participants should not be added")
+	}
+
+	_, _, _, err = applyAuditFields(doc, "me@example.com", "now", UpdateAuditFieldsOptions{DueDate: &due, ClearDue: true})
+	if err == nil {
+		t.Error("expected an error for setting and clearing the due date")
+	}
+
+	_, _, _, err = applyAuditFields(doc, "me@example.com", "now", UpdateAuditFieldsOptions{ClearDue: true})
+	if err != nil {
+		t.Fatalf("applyAuditFields: %v", err)
+	}
+	if _, ok := doc["dates"].(map[string]interface{})["dueDate"]; ok {
+		t.Error("dueDate should be removed")
+	}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BulkResult is the outcome of a bulk operation for a single ticket. Unknown is set
+// when the response doesn't say what happened to the ticket.
+type BulkResult struct {
+	ID      string `json:"id"`
+	OK      bool   `json:"ok"`
+	Unknown bool   `json:"unknown,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkDeleteTickets deletes multiple tickets in a single request to the bulk endpoint.
+// Returns one result per ticket ID, see parseBulkResults.
+func (c *Client) BulkDeleteTickets(database string, ticketIDs []string) ([]BulkResult, error) {
+	now := time.Now().UTC()
+	timeOnly := now.Format("15:04:05")
+
+	reqBody := map[string]interface{}{
+		"status":         "",
+		"database":       database,
+		"channelId":      "",
+		"tags":           nil,
+		"progressLabels": nil,
+		"time":           timeOnly,
+		"operationType":  "delete",
+		"replaceTag":     "",
+		"modules":        []string{},
+		"roles":          nil,
+		"documentIds":    ticketIDs,
+		"platform": map[string]string{
+			"userInterface":    "cli",
+			"interfaceVersion": "1.0.0",
+		},
+		"actionOnRoles": []string{},
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	body, err := c.doRequest("POST", "/api/v1/bulk/ticket", strings.NewReader(string(jsonBody)))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBulkResults(body, ticketIDs), nil
+}
+
+// parseBulkResults maps the bulk endpoint response to one result per requested ID.
+// When the response is a CouchDB-style list of {id, ok, error, reason} objects, IDs
+// missing from it are reported as unknown. Any other successful response has no
+// per-ticket results, so all tickets are reported as done.
+func parseBulkResults(body []byte, ids []string) []BulkResult {
+	var raw []struct {
+		ID     string `json:"id"`
+		OK     *bool  `json:"ok"`
+		Error  string `json:"error"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		results := make([]BulkResult, 0, len(ids))
+		for _, id := range ids {
+			results = append(results, BulkResult{ID: id, OK: true})
+		}
+		return results
+	}
+
+	byID := make(map[string]BulkResult, len(raw))
+	for _, r := range raw {
+		if r.ID == "" {
+			continue
+		}
+		result := BulkResult{ID: r.ID, OK: r.Error == "" && (r.OK == nil || *r.OK)}
+		if r.Error != "" {
+			result.Error = r.Error
+			if r.Reason != "" {
+				result.Error += ": " + r.Reason
+			}
+		}
+		byID[r.ID] = result
+	}
+
+	results := make([]BulkResult, 0, len(ids))
+	for _, id := range ids {
+		if r, ok := byID[id]; ok {
+			results = append(results, r)
+		} else {
+			results = append(results, BulkResult{ID: id, Unknown: true, Error: "no result in response"})
+		}
+	}
+	return results
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBulkResults(t *testing.T) {
+	ids := []string{"a1", "b2", "c3"}
+	unknown := func(id string) BulkResult {
+		return BulkResult{ID: id, Unknown: true, Error: "no result in response"}
+	}
+	ok := func(id string) BulkResult {
+		return BulkResult{ID: id, OK: true}
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want []BulkResult
+	}{
+		{
+			name: "empty response is success",
+			body: ``,
+			want: []BulkResult{ok("a1"), ok("b2"), ok("c3")},
+		},
+		{
+			name: "non-list response is success",
+			body: `{"message":"ok"}`,
+			want: []BulkResult{ok("a1"), ok("b2"), ok("c3")},
+		},
+		{
+			name: "per-document results",
+			body: `[{"id":"a1","ok":true},{"id":"b2","error":"conflict","reason":"Document update conflict."},{"id":"c3","ok":false}]`,
+			want: []BulkResult{
+				{ID: "a1", OK: true},
+				{ID: "b2", OK: false, Error: "conflict: Document update conflict."},
+				{ID: "c3", OK: false},
+			},
+		},
+		{
+			name: "IDs missing from the response",
+			body: `[{"id":"b2","error":"forbidden"}]`,
+			want: []BulkResult{unknown("a1"), {ID: "b2", OK: false, Error: "forbidden"}, unknown("c3")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseBulkResults([]byte(tt.body), ids)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBulkResults() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -305,9 +306,12 @@ type Question struct {
 
 // QuestionSettings holds settings for a question
 type QuestionSettings struct {
-	AnswerType string   `json:"answertype,omitempty"`
-	Choice     string   `json:"choice,omitempty"`
-	Answer     []string `json:"answer,omitempty"` // Predefined options for multiplechoice
+	AnswerType  string           `json:"answertype,omitempty"`
+	Required    bool             `json:"required,omitempty"` // Must be answered before the audit can be completed
+	Choice      string           `json:"choice,omitempty"`
+	Answer      []string         `json:"answer,omitempty"` // Predefined options for multiplechoice
+	RichOptions []RichOption     `json:"richOptions,omitempty"`
+	Styling     *QuestionStyling `json:"styling,omitempty"`
 }
 
 // TemplateDates holds date fields for a template
@@ -601,14 +605,6 @@ func (c *Client) GetTicket(database, ticketID string) (*Ticket, error) {
 	return &ticket, nil
 }
 
-// UpdateTicketOptions contains options for updating a ticket
-type UpdateTicketOptions struct {
-	Status      *string
-	Responsible *string
-	DueDate     *string
-	Tags        []string
-}
-
 // UpdateTicketFieldsOptions contains options for updating ticket fields with operation tracking
 type UpdateTicketFieldsOptions struct {
 	Title            *string
@@ -617,56 +613,11 @@ type UpdateTicketFieldsOptions struct {
 	ClearDue         bool
 	Responsible      *string // Email of the responsible person
 	ClearResponsible bool    // Clear the responsible (sets status back to created)
-	Complete         bool    // Mark ticket as completed (requires Responsible to be set)
+	Complete         bool    // Mark ticket as completed (uses the existing responsible or the current user)
+	Reopen           bool    // Reopen a completed ticket (status back to started, or created without responsible)
 	Comment          *string // Add a comment to the ticket
 }
 
-// UpdateTicket updates a ticket via the securedata endpoint
-func (c *Client) UpdateTicket(database, ticketID string, opts UpdateTicketOptions) error {
-	// First, get the current document
-	getEndpoint := fmt.Sprintf("/api/v1/securedata/%s/%s", url.PathEscape(database), url.PathEscape(ticketID))
-	body, err := c.doRequest("GET", getEndpoint, nil)
-	if err != nil {
-		return fmt.Errorf("fetching ticket: %w", err)
-	}
-
-	var doc map[string]interface{}
-	if err := json.Unmarshal(body, &doc); err != nil {
-		return fmt.Errorf("parsing ticket: %w", err)
-	}
-
-	// Apply updates
-	if opts.Status != nil {
-		doc["status"] = *opts.Status
-	}
-	if opts.Responsible != nil {
-		if participants, ok := doc["participants"].(map[string]interface{}); ok {
-			participants["responsible"] = *opts.Responsible
-		} else {
-			doc["participants"] = map[string]interface{}{"responsible": *opts.Responsible}
-		}
-	}
-	if opts.DueDate != nil {
-		if dates, ok := doc["dates"].(map[string]interface{}); ok {
-			dates["dueDate"] = *opts.DueDate
-		} else {
-			doc["dates"] = map[string]interface{}{"dueDate": *opts.DueDate}
-		}
-	}
-	if opts.Tags != nil {
-		doc["tags"] = opts.Tags
-	}
-
-	// PUT the updated document
-	jsonBody, err := json.Marshal(doc)
-	if err != nil {
-		return fmt.Errorf("marshaling ticket: %w", err)
-	}
-
-	_, err = c.doRequest("PUT", getEndpoint, strings.NewReader(string(jsonBody)))
-	return err
-}
-
 // ListAuditsOptions contains options for listing audits
 type ListAuditsOptions struct {
 	Database    string // Required
@@ -1589,75 +1540,6 @@ func (c *Client) CreateFile(opts CreateFileOptions) (*CreateFileResponse, error)
 	return &result, nil
 }
 
-// UpdateDocumentTags updates the tags on a document (file or map)
-func (c *Client) UpdateDocumentTags(database, docID string, tags []string) error {
-	// Get the current document
-	doc, err := c.GetDocument(database, docID)
-	if err != nil {
-		return fmt.Errorf("getting document: %w", err)
-	}
-
-	// Get user email for operation record
-	email, err := c.Email()
-	if err != nil {
-		return fmt.Errorf("getting user email: %w", err)
-	}
-
-	// Get old tags for operation record
-	var oldTags []string
-	if existingTags, ok := doc["tags"].([]interface{}); ok {
-		for _, t := range existingTags {
-			if s, ok := t.(string); ok {
-				oldTags = append(oldTags, s)
-			}
-		}
-	}
-
-	// Update tags
-	doc["tags"] = tags
-
-	// Update dates.lastModifiedDate
-	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
-	if dates, ok := doc["dates"].(map[string]interface{}); ok {
-		dates["lastModifiedDate"] = now
-	}
-
-	// Update content.lastModifier if it exists
-	if content, ok := doc["content"].(map[string]interface{}); ok {
-		content["lastModifier"] = email
-	}
-
-	// Build operation record
-	operation := map[string]interface{}{
-		"author":            email,
-		"changedProperties": []string{"tags"},
-		"oldValues":         []interface{}{oldTags},
-		"newValues":         []interface{}{tags},
-		"time":              now,
-		"platform": map[string]string{
-			"userInterface":    "cli",
-			"interfaceVersion": "1.0.0",
-		},
-	}
-
-	// Append to operations array
-	if ops, ok := doc["operation"].([]interface{}); ok {
-		doc["operation"] = append(ops, operation)
-	} else {
-		doc["operation"] = []interface{}{operation}
-	}
-
-	// PUT the updated document
-	jsonBody, err := json.Marshal(doc)
-	if err != nil {
-		return fmt.Errorf("marshaling document: %w", err)
-	}
-
-	endpoint := fmt.Sprintf("/api/v1/securedata/%s/%s", url.PathEscape(database), url.PathEscape(docID))
-	_, err = c.doRequest("PUT", endpoint, strings.NewReader(string(jsonBody)))
-	return err
-}
-
 // UpdateTicketDueDate updates the due date on a ticket
 // If dueDate is empty, the due date is cleared
 func (c *Client) UpdateTicketDueDate(database, ticketID string, dueDate string) error {
@@ -1770,6 +1652,8 @@ func (c *Client) UpdateTicketFields(database, ticketID string, opts UpdateTicket
 		return fmt.Errorf("getting user email: %w", err)
 	}
 
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+
 	// Track changes for operation record
 	var changedProps []string
 	var oldValues []interface{}
@@ -1833,117 +1717,17 @@ func (c *Client) UpdateTicketFields(database, ticketID string, opts UpdateTicket
 		newValues = append(newValues, newDueDate)
 	}
 
-	// Handle responsible update and completion
-	if opts.Responsible != nil || opts.ClearResponsible || opts.Complete {
-		// Get old responsible email
-		oldResponsible := ""
-		if participants, ok := doc["participants"].(map[string]interface{}); ok {
-			if resp, ok := participants["responsible"].(map[string]interface{}); ok {
-				if email, ok := resp["email"].(string); ok {
-					oldResponsible = email
-				}
-			}
-		}
-
-		// Get old status
-		oldStatus := ""
-		if state, ok := doc["state"].(map[string]interface{}); ok {
-			if s, ok := state["state"].(string); ok {
-				oldStatus = s
-			}
-		}
-
-		newResponsible := ""
-		newStatus := ""
-
-		if opts.ClearResponsible {
-			// Clear responsible and set status to created
-			if participants, ok := doc["participants"].(map[string]interface{}); ok {
-				delete(participants, "responsible")
-			}
-			newStatus = "created"
-		} else if opts.Responsible != nil {
-			// Set responsible
-			newResponsible = *opts.Responsible
-			if participants, ok := doc["participants"].(map[string]interface{}); ok {
-				participants["responsible"] = map[string]interface{}{
-					"type":  "IB.EdBundle.Document.Person",
-					"email": *opts.Responsible,
-				}
-			} else {
-				doc["participants"] = map[string]interface{}{
-					"type": "IB.EdBundle.Document.Participants",
-					"responsible": map[string]interface{}{
-						"type":  "IB.EdBundle.Document.Person",
-						"email": *opts.Responsible,
-					},
-					"consulted": []interface{}{},
-					"informed":  []interface{}{},
-				}
-			}
-
-			// Determine new status based on completion flag
-			if opts.Complete {
-				newStatus = "completed"
-			} else {
-				newStatus = "started"
-			}
-		} else if opts.Complete {
-			// Complete without providing responsible - use existing responsible or current user
-			if oldResponsible != "" {
-				newResponsible = oldResponsible
-			} else {
-				// Use current user as responsible
-				newResponsible = email
-				if participants, ok := doc["participants"].(map[string]interface{}); ok {
-					participants["responsible"] = map[string]interface{}{
-						"type":  "IB.EdBundle.Document.Person",
-						"email": email,
-					}
-				} else {
-					doc["participants"] = map[string]interface{}{
-						"type": "IB.EdBundle.Document.Participants",
-						"responsible": map[string]interface{}{
-							"type":  "IB.EdBundle.Document.Person",
-							"email": email,
-						},
-						"consulted": []interface{}{},
-						"informed":  []interface{}{},
-					}
-				}
-			}
-			newStatus = "completed"
-		}
-
-		// Update state
-		if newStatus != "" && newStatus != oldStatus {
-			if state, ok := doc["state"].(map[string]interface{}); ok {
-				state["state"] = newStatus
-			} else {
-				doc["state"] = map[string]interface{}{
-					"type":  "IB.EdBundle.Document.State",
-					"state": newStatus,
-				}
-			}
-
-			// Add status change to operation record
-			changedProps = append(changedProps, "status")
-			oldValues = append(oldValues, oldStatus)
-			newValues = append(newValues, newStatus)
-		}
-
-		// Add responsible change to operation record (only if responsible actually changed)
-		if newResponsible != oldResponsible || opts.ClearResponsible {
-			changedProps = append(changedProps, "responsible")
-			oldValues = append(oldValues, oldResponsible)
-			newValues = append(newValues, newResponsible)
-		}
+	// Handle responsible and status changes
+	props, oldVals, newVals, err := applyTicketLifecycle(doc, email, now, opts)
+	if err != nil {
+		return err
 	}
+	changedProps = append(changedProps, props...)
+	oldValues = append(oldValues, oldVals...)
+	newValues = append(newValues, newVals...)
 
 	// Handle adding a comment
 	if opts.Comment != nil && *opts.Comment != "" {
-		now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
-
 		// Create the comment object
 		comment := map[string]interface{}{
 			"attachments":  []interface{}{},
@@ -1973,7 +1757,6 @@ func (c *Client) UpdateTicketFields(database, ticketID string, opts UpdateTicket
 	}
 
 	// Update dates.lastModifiedDate
-	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
 	if dates, ok := doc["dates"].(map[string]interface{}); ok {
 		dates["lastModifiedDate"] = now
 	}
@@ -2019,6 +1802,232 @@ func (c *Client) UpdateTicketFields(database, ticketID string, opts UpdateTicket
 	return err
 }
 
+// AttachmentFile is a file stored as an inline CouchDB attachment on a document
+type AttachmentFile struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// CreateTicketOptions contains options for creating a ticket
+type CreateTicketOptions struct {
+	Database    string // Required
+	Title       string // Required
+	Description string
+	Responsible string   // Email of the responsible person (sets status to started)
+	DueDate     string   // ISO 8601 due date
+	Tags        []string // Optional tags
+	MapID       string   // Map (drawing) to pin the ticket on
+	X           float64  // Pin position on the map
+	Y           float64
+	Photos      []AttachmentFile
+}
+
+// CreateTicket creates a new ticket document and returns its CouchDB ID
+func (c *Client) CreateTicket(opts CreateTicketOptions) (string, error) {
+	email, err := c.Email()
+	if err != nil {
+		return "", fmt.Errorf("getting user email: %w", err)
+	}
+
+	project, err := c.GetProject(opts.Database)
+	if err != nil {
+		return "", fmt.Errorf("getting project: %w", err)
+	}
+
+	timestamp := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	doc := newTicketDocument(opts, project.CouchDbID, email, timestamp)
+
+	// Pin the ticket on a map (the ticket's groupId is the map's group)
+	if opts.MapID != "" {
+		m, err := c.GetMap(opts.Database, opts.MapID)
+		if err != nil {
+			return "", fmt.Errorf("getting map: %w", err)
+		}
+		doc["groupId"] = m.GroupID
+	}
+
+	return c.createDocument(opts.Database, doc)
+}
+
+// newTicketDocument builds the document of a new ticket in the given project
+// (the project's CouchDB ID), created by email at timestamp. The groupId of a
+// ticket pinned on a map is left to the caller.
+func newTicketDocument(opts CreateTicketOptions, projectID, email, timestamp string) map[string]interface{} {
+	participants := map[string]interface{}{
+		"type":      "IB.EdBundle.Document.Participants",
+		"consulted": []interface{}{},
+		"informed":  []interface{}{},
+	}
+	status := "created"
+	if opts.Responsible != "" {
+		participants["responsible"] = map[string]interface{}{
+			"type":  "IB.EdBundle.Document.Person",
+			"email": opts.Responsible,
+		}
+		status = "started"
+	}
+
+	plan := map[string]interface{}{}
+	if opts.DueDate != "" {
+		plan["dueDate"] = opts.DueDate
+	}
+
+	tags := opts.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	doc := map[string]interface{}{
+		"type":     "IB.EdBundle.Document.Ticket",
+		"project":  projectID,
+		"archived": nil,
+		"content": map[string]interface{}{
+			"title": opts.Title,
+			"body":  opts.Description,
+			"author": map[string]interface{}{
+				"type":  "IB.EdBundle.Document.Person",
+				"email": email,
+			},
+			"lastmodifier": map[string]interface{}{
+				"type":  "IB.EdBundle.Document.Person",
+				"email": email,
+			},
+		},
+		"state": map[string]interface{}{
+			"type":  "IB.EdBundle.Document.State",
+			"state": status,
+		},
+		"participants": participants,
+		"plan":         plan,
+		"dates": map[string]interface{}{
+			"creationDate":     timestamp,
+			"lastModifiedDate": timestamp,
+		},
+		"tags":     tags,
+		"comments": []interface{}{},
+		"operation": []interface{}{
+			map[string]interface{}{
+				"changedProperties": []string{"ticket"},
+				"oldValues":         []interface{}{""},
+				"newValues":         []interface{}{opts.Title},
+				"author":            email,
+				"time":              timestamp,
+				"summary":           "Ticket created via CLI",
+				"actionType":        "created",
+				"platform": map[string]string{
+					"userInterface":    "cli",
+					"interfaceVersion": "1.0.0",
+				},
+			},
+		},
+	}
+
+	if opts.MapID != "" {
+		doc["map"] = opts.MapID
+		doc["position"] = map[string]interface{}{
+			"x": opts.X,
+			"y": opts.Y,
+		}
+	}
+
+	if len(opts.Photos) > 0 {
+		reserveAttachmentNames(doc, opts.Photos)
+		doc["_attachments"] = inlineAttachments(opts.Photos)
+	}
+
+	return doc
+}
+
+// appendOperation appends an "updated" operation record to a document's operation log
+func appendOperation(doc map[string]interface{}, email, timestamp string, changedProps []string, oldValues, newValues []interface{}) {
+	operation := map[string]interface{}{
+		"author":            email,
+		"changedProperties": changedProps,
+		"oldValues":         oldValues,
+		"newValues":         newValues,
+		"time":              timestamp,
+		"summary":           "user updated following fields",
+		"actionType":        "updated",
+		"platform": map[string]string{
+			"userInterface":    "cli",
+			"interfaceVersion": "1.0.0",
+		},
+	}
+
+	if ops, ok := doc["operation"].([]interface{}); ok {
+		doc["operation"] = append(ops, operation)
+	} else {
+		doc["operation"] = []interface{}{operation}
+	}
+}
+
+// markModified updates dates.lastModifiedDate and the last modifier of a document.
+// The modifier is stored the way the document type stores it: an email address in
+// content.lastModifier for files and maps, {"email"} in lastmodifier for templates
+// and a person in content.lastmodifier for tickets and audits.
+func markModified(doc map[string]interface{}, email, timestamp string) {
+	if dates, ok := doc["dates"].(map[string]interface{}); ok {
+		dates["lastModifiedDate"] = timestamp
+	}
+
+	docType, _ := doc["type"].(string)
+	if docType == "IB.EdBundle.Document.AuditTemplate" {
+		doc["lastmodifier"] = map[string]string{
+			"email": email,
+		}
+		return
+	}
+
+	content, ok := doc["content"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if _, isFile := content["lastModifier"]; isFile || docType == "IB.EdBundle.Document.File" {
+		content["lastModifier"] = email
+		return
+	}
+	if _, isEmail := content["lastmodifier"].(string); isEmail {
+		content["lastmodifier"] = email
+		return
+	}
+	content["lastmodifier"] = map[string]interface{}{
+		"type":  "IB.EdBundle.Document.Person",
+		"email": email,
+	}
+}
+
+// addInlineAttachments adds files to a document's _attachments, keeping existing entries
+func addInlineAttachments(doc map[string]interface{}, files []AttachmentFile) {
+	attachments, ok := doc["_attachments"].(map[string]interface{})
+	if !ok {
+		attachments = make(map[string]interface{})
+		doc["_attachments"] = attachments
+	}
+	for name, att := range inlineAttachments(files) {
+		attachments[name] = att
+	}
+}
+
+// inlineAttachments converts files to the CouchDB inline attachment format
+// (base64 encoded data keyed by attachment name). A thumbnail is added for each image.
+func inlineAttachments(files []AttachmentFile) map[string]interface{} {
+	attachments := make(map[string]interface{}, len(files))
+	for _, f := range files {
+		attachments[f.Name] = map[string]interface{}{
+			"content_type": f.ContentType,
+			"data":         base64.StdEncoding.EncodeToString(f.Data),
+		}
+		if thumb, ok := makeThumbnail(f); ok {
+			attachments[thumb.Name] = map[string]interface{}{
+				"content_type": thumb.ContentType,
+				"data":         base64.StdEncoding.EncodeToString(thumb.Data),
+			}
+		}
+	}
+	return attachments
+}
+
 // DeleteLibraryItems deletes files and/or maps from a project
 func (c *Client) DeleteLibraryItems(database string, fileIDs, mapIDs []string) error {
 	// Get project info for channelId
@@ -2275,34 +2284,7 @@ func (c *Client) ArchiveTicket(database, ticketID string, archive bool) error {
 
 // DeleteTickets deletes tickets using the bulk endpoint
 func (c *Client) DeleteTickets(database string, ticketIDs []string) error {
-	now := time.Now().UTC()
-	timeOnly := now.Format("15:04:05")
-
-	reqBody := map[string]interface{}{
-		"status":         "",
-		"database":       database,
-		"channelId":      "",
-		"tags":           nil,
-		"progressLabels": nil,
-		"time":           timeOnly,
-		"operationType":  "delete",
-		"replaceTag":     "",
-		"modules":        []string{},
-		"roles":          nil,
-		"documentIds":    ticketIDs,
-		"platform": map[string]string{
-			"userInterface":    "cli",
-			"interfaceVersion": "1.0.0",
-		},
-		"actionOnRoles": []string{},
-	}
-
-	jsonBody, err := json.Marshal(reqBody)
-	if err != nil {
-		return fmt.Errorf("marshaling request: %w", err)
-	}
-
-	_, err = c.doRequest("POST", "/api/v1/bulk/ticket", strings.NewReader(string(jsonBody)))
+	_, err := c.BulkDeleteTickets(database, ticketIDs)
 	return err
 }
 
@@ -2372,28 +2354,7 @@ func (c *Client) CreateTemplateGroup(database, name string) (string, error) {
 		"type":     "IB.EdBundle.Document.TemplateGroup",
 	}
 
-	jsonBody, err := json.Marshal(doc)
-	if err != nil {
-		return "", fmt.Errorf("marshaling document: %w", err)
-	}
-
-	endpoint := fmt.Sprintf("/api/v1/securedata/%s", url.PathEscape(database))
-
-	respBody, err := c.doRequest("POST", endpoint, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		return "", err
-	}
-
-	// Parse response to get the new document ID
-	var resp struct {
-		ID  string `json:"id"`
-		Rev string `json:"rev"`
-	}
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("parsing response: %w", err)
-	}
-
-	return resp.ID, nil
+	return c.createDocument(database, doc)
 }
 
 // UpdateTemplateGroup updates a template group's fields
@@ -2483,27 +2444,7 @@ func (c *Client) CreateFileGroup(database, name string) (string, error) {
 		"type":     "IB.EdBundle.Document.FileGroup",
 	}
 
-	jsonBody, err := json.Marshal(doc)
-	if err != nil {
-		return "", fmt.Errorf("marshaling document: %w", err)
-	}
-
-	endpoint := fmt.Sprintf("/api/v1/securedata/%s", url.PathEscape(database))
-
-	respBody, err := c.doRequest("POST", endpoint, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		return "", err
-	}
-
-	var resp struct {
-		ID  string `json:"id"`
-		Rev string `json:"rev"`
-	}
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("parsing response: %w", err)
-	}
-
-	return resp.ID, nil
+	return c.createDocument(database, doc)
 }
 
 // CreateAuditTemplateOptions contains options for creating an audit template
@@ -2591,12 +2532,17 @@ func (c *Client) CreateAuditTemplate(opts CreateAuditTemplateOptions) (string, e
 		"answeredStatusEnabled": true,
 	}
 
+	return c.createDocument(opts.Database, doc)
+}
+
+// createDocument creates a new document in a project database and returns its ID
+func (c *Client) createDocument(database string, doc map[string]interface{}) (string, error) {
 	jsonBody, err := json.Marshal(doc)
 	if err != nil {
 		return "", fmt.Errorf("marshaling document: %w", err)
 	}
 
-	endpoint := fmt.Sprintf("/api/v1/securedata/%s", url.PathEscape(opts.Database))
+	endpoint := fmt.Sprintf("/api/v1/securedata/%s", url.PathEscape(database))
 
 	respBody, err := c.doRequest("POST", endpoint, strings.NewReader(string(jsonBody)))
 	if err != nil {
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewTicketDocument(t *testing.T) {
+	const (
+		email     = "jan@example.com"
+		timestamp = "2026-03-10T09:00:00.000Z"
+	)
+	person := map[string]interface{}{"type": "IB.EdBundle.Document.Person", "email": email}
+
+	t.Run("minimal", func(t *testing.T) {
+		doc := newTicketDocument(CreateTicketOptions{Title: "Crack in wall"}, "project-1", email, timestamp)
+
+		if doc["type"] != "IB.EdBundle.Document.Ticket" || doc["project"] != "project-1" {
+			t.Errorf("type/project = %v/%v", doc["type"], doc["project"])
+		}
+		content := doc["content"].(map[string]interface{})
+		if content["title"] != "Crack in wall" || content["body"] != "" {
+			t.Errorf("content = %v", content)
+		}
+		if !reflect.DeepEqual(content["author"], person) || !reflect.DeepEqual(content["lastmodifier"], person) {
+			t.Errorf("author/lastmodifier = %v/%v, want %v", content["author"], content["lastmodifier"], person)
+		}
+		if state := doc["state"].(map[string]interface{}); state["state"] != "created" {
+			t.Errorf("state = %v, want created", state["state"])
+		}
+		if _, ok := doc["participants"].(map[string]interface{})["responsible"]; ok {
+			t.Error("unexpected responsible")
+		}
+		if plan := doc["plan"].(map[string]interface{}); len(plan) != 0 {
+			t.Errorf("plan = %v, want empty", plan)
+		}
+		if tags := doc["tags"].([]string); tags == nil || len(tags) != 0 {
+			t.Errorf("tags = %#v, want empty list", tags)
+		}
+		dates := doc["dates"].(map[string]interface{})
+		if dates["creationDate"] != timestamp || dates["lastModifiedDate"] != timestamp {
+			t.Errorf("dates = %v", dates)
+		}
+		ops := doc["operation"].([]interface{})
+		if len(ops) != 1 || ops[0].(map[string]interface{})["actionType"] != "created" {
+			t.Errorf("operation = %v", ops)
+		}
+		for _, key := range []string{"map", "groupId", "position", "_attachments"} {
+			if _, ok := doc[key]; ok {
+				t.Errorf("unexpected %s", key)
+			}
+		}
+	})
+
+	t.Run("full", func(t *testing.T) {
+		opts := CreateTicketOptions{
+			Title:       "Missing fire stop",
+			Description: "<p>Level 2</p>",
+			Responsible: "piet@example.com",
+			DueDate:     "2026-03-15T23:59:59.000Z",
+			Tags:        []string{"snag"},
+			MapID:       "map-1",
+			X:           412.5,
+			Y:           230,
+			Photos:      []AttachmentFile{{Name: "door.pdf", ContentType: "application/pdf", Data: []byte("%PDF")}},
+		}
+		doc := newTicketDocument(opts, "project-1", email, timestamp)
+
+		if state := doc["state"].(map[string]interface{}); state["state"] != "started" {
+			t.Errorf("state = %v, want started", state["state"])
+		}
+		responsible := doc["participants"].(map[string]interface{})["responsible"]
+		if !reflect.DeepEqual(responsible, map[string]interface{}{"type": "IB.EdBundle.Document.Person", "email": "piet@example.com"}) {
+			t.Errorf("responsible = %v", responsible)
+		}
+		if plan := doc["plan"].(map[string]interface{}); plan["dueDate"] != opts.DueDate {
+			t.Errorf("dueDate = %v, want %s", plan["dueDate"], opts.DueDate)
+		}
+		if !reflect.DeepEqual(doc["tags"], []string{"snag"}) {
+			t.Errorf("tags = %v", doc["tags"])
+		}
+		if doc["map"] != "map-1" || !reflect.DeepEqual(doc["position"], map[string]interface{}{"x": 412.5, "y": 230.0}) {
+			t.Errorf("map/position = %v/%v", doc["map"], doc["position"])
+		}
+		attachments := doc["_attachments"].(map[string]interface{})
+		if _, ok := attachments["door.pdf"]; !ok || len(attachments) != 1 {
+			t.Errorf("_attachments = %v", attachments)
+		}
+	})
+}
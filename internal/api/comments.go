@@ -0,0 +1,238 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Comment represents a comment in a ticket's comment thread
+type Comment struct {
+	Index        int      `json:"index"` // 1-based position in the thread
+	Author       string   `json:"author"`
+	Time         string   `json:"time"`
+	Note         string   `json:"note"`
+	Public       bool     `json:"public"`
+	AllowedUsers []string `json:"allowedUsers,omitempty"`
+	Attachments  []string `json:"attachments,omitempty"`
+}
+
+// AddCommentOptions contains options for adding a comment
+type AddCommentOptions struct {
+	Note         string
+	Private      bool     // Only visible to AllowedUsers (and the author)
+	AllowedUsers []string // Emails that may see a private comment
+	Attachments  []AttachmentFile
+}
+
+// ListComments returns the comment thread of a document in chronological order
+func (c *Client) ListComments(database, docID string) ([]Comment, error) {
+	doc, err := c.GetDocument(database, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, _ := doc["comments"].([]interface{})
+	comments := make([]Comment, 0, len(raw))
+	for i, r := range raw {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		comments = append(comments, parseComment(i+1, m))
+	}
+
+	return comments, nil
+}
+
+// parseComment converts a raw comment object into a Comment.
+// The author can be stored as a plain email or as a Person object.
+func parseComment(index int, m map[string]interface{}) Comment {
+	comment := Comment{Index: index, Public: true}
+
+	switch a := m["author"].(type) {
+	case string:
+		comment.Author = a
+	case map[string]interface{}:
+		comment.Author, _ = a["email"].(string)
+	}
+
+	comment.Time, _ = m["time"].(string)
+	comment.Note, _ = m["note"].(string)
+
+	if public, ok := m["public"].(bool); ok {
+		comment.Public = public
+	}
+
+	if users, ok := m["allowedUsers"].(string); ok && users != "" {
+		for _, u := range strings.Split(users, ",") {
+			if u = strings.TrimSpace(u); u != "" {
+				comment.AllowedUsers = append(comment.AllowedUsers, u)
+			}
+		}
+	}
+
+	if atts, ok := m["attachments"].([]interface{}); ok {
+		for _, a := range atts {
+			switch v := a.(type) {
+			case string:
+				comment.Attachments = append(comment.Attachments, v)
+			case map[string]interface{}:
+				if name, ok := v["name"].(string); ok {
+					comment.Attachments = append(comment.Attachments, name)
+				}
+			}
+		}
+	}
+
+	return comment
+}
+
+// AddComment appends a comment to a document's comment thread.
+// Attachments are stored as inline CouchDB attachments and referenced by name from the comment;
+// files whose name is already taken get a numeric suffix.
+func (c *Client) AddComment(database, docID string, opts AddCommentOptions) error {
+	doc, err := c.GetDocument(database, docID)
+	if err != nil {
+		return fmt.Errorf("getting document: %w", err)
+	}
+
+	email, err := c.Email()
+	if err != nil {
+		return fmt.Errorf("getting user email: %w", err)
+	}
+
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+
+	attachmentNames := []interface{}{}
+	for _, name := range reserveAttachmentNames(doc, opts.Attachments) {
+		attachmentNames = append(attachmentNames, name)
+	}
+	if len(opts.Attachments) > 0 {
+		addInlineAttachments(doc, opts.Attachments)
+	}
+
+	allowedUsers := ""
+	if opts.Private {
+		allowedUsers = strings.Join(opts.AllowedUsers, ",")
+	}
+
+	comment := map[string]interface{}{
+		"attachments":  attachmentNames,
+		"author":       email,
+		"time":         now,
+		"note":         opts.Note,
+		"public":       !opts.Private,
+		"allowedUsers": allowedUsers,
+	}
+
+	if comments, ok := doc["comments"].([]interface{}); ok {
+		doc["comments"] = append(comments, comment)
+	} else {
+		doc["comments"] = []interface{}{comment}
+	}
+
+	markModified(doc, email, now)
+	appendOperation(doc, email, now, []string{"comment"}, []interface{}{nil}, []interface{}{opts.Note})
+
+	return c.UpdateDocument(database, docID, doc)
+}
+
+// EditComment replaces the text of the comment at the given 1-based index. The edit
+// is recorded in the operation log.
+func (c *Client) EditComment(database, docID string, index int, note string) error {
+	doc, err := c.GetDocument(database, docID)
+	if err != nil {
+		return fmt.Errorf("getting document: %w", err)
+	}
+
+	comment, err := commentAt(doc, index)
+	if err != nil {
+		return err
+	}
+
+	email, err := c.Email()
+	if err != nil {
+		return fmt.Errorf("getting user email: %w", err)
+	}
+
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+
+	oldNote, _ := comment["note"].(string)
+	comment["note"] = note
+
+	markModified(doc, email, now)
+	appendOperation(doc, email, now, []string{"comment"}, []interface{}{oldNote}, []interface{}{note})
+
+	return c.UpdateDocument(database, docID, doc)
+}
+
+// DeleteComment removes the comment at the given 1-based index from the thread.
+// Attachments referenced only by this comment are removed as well, including their thumbnails.
+func (c *Client) DeleteComment(database, docID string, index int) error {
+	doc, err := c.GetDocument(database, docID)
+	if err != nil {
+		return fmt.Errorf("getting document: %w", err)
+	}
+
+	comment, err := commentAt(doc, index)
+	if err != nil {
+		return err
+	}
+
+	email, err := c.Email()
+	if err != nil {
+		return fmt.Errorf("getting user email: %w", err)
+	}
+
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+
+	comments := doc["comments"].([]interface{})
+	doc["comments"] = append(comments[:index-1:index-1], comments[index:]...)
+
+	removed := parseComment(index, comment)
+	removeUnreferencedAttachments(doc, removed.Attachments)
+
+	markModified(doc, email, now)
+	appendOperation(doc, email, now, []string{"comment"}, []interface{}{removed.Note}, []interface{}{nil})
+
+	return c.UpdateDocument(database, docID, doc)
+}
+
+// removeUnreferencedAttachments removes the named attachments (and their thumbnails)
+// from a document, except those still referenced by one of its comments
+func removeUnreferencedAttachments(doc map[string]interface{}, names []string) {
+	attachments, ok := doc["_attachments"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	referenced := make(map[string]bool)
+	comments, _ := doc["comments"].([]interface{})
+	for i, c := range comments {
+		if m, ok := c.(map[string]interface{}); ok {
+			for _, name := range parseComment(i+1, m).Attachments {
+				referenced[name] = true
+			}
+		}
+	}
+
+	for _, name := range names {
+		if !referenced[name] {
+			removeAttachment(attachments, name)
+		}
+	}
+}
+
+// commentAt returns the raw comment object at the given 1-based index
+func commentAt(doc map[string]interface{}, index int) (map[string]interface{}, error) {
+	comments, _ := doc["comments"].([]interface{})
+	if index < 1 || index > len(comments) {
+		return nil, fmt.Errorf("comment %d not found (document has %d comments)", index, len(comments))
+	}
+	comment, ok := comments[index-1].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("comment %d has an unexpected format", index)
+	}
+	return comment, nil
+}
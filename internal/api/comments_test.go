@@ -0,0 +1,112 @@
+package api
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseComment(t *testing.T) {
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		want  Comment
+	}{
+		{
+			name: "public comment written by the CLI",
+			input: map[string]interface{}{
+				"attachments":  []interface{}{},
+				"author":       "john@example.com",
+				"time":         "2026-03-03T11:23:30.000Z",
+				"note":         "Looks good",
+				"public":       true,
+				"allowedUsers": "",
+			},
+			want: Comment{Index: 1, Author: "john@example.com", Time: "2026-03-03T11:23:30.000Z", Note: "Looks good", Public: true},
+		},
+		{
+			name: "author as person object",
+			input: map[string]interface{}{
+				"author": map[string]interface{}{"type": "IB.EdBundle.Document.Person", "email": "jane@example.com"},
+				"note":   "From the web app",
+			},
+			want: Comment{Index: 1, Author: "jane@example.com", Note: "From the web app", Public: true},
+		},
+		{
+			name: "private comment with allowed users and attachments",
+			input: map[string]interface{}{
+				"author":       "john@example.com",
+				"note":         "Internal",
+				"public":       false,
+				"allowedUsers": "a@example.com, b@example.com",
+				"attachments":  []interface{}{"20260303-112330.jpg", map[string]interface{}{"name": "plan.pdf"}},
+			},
+			want: Comment{
+				Index:        1,
+				Author:       "john@example.com",
+				Note:         "Internal",
+				Public:       false,
+				AllowedUsers: []string{"a@example.com", "b@example.com"},
+				Attachments:  []string{"20260303-112330.jpg", "plan.pdf"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseComment(1, tt.input)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseComment() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentAt(t *testing.T) {
+	doc := map[string]interface{}{
+		"comments": []interface{}{
+			map[string]interface{}{"note": "first"},
+			map[string]interface{}{"note": "second"},
+		},
+	}
+
+	c, err := commentAt(doc, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c["note"] != "second" {
+		t.Errorf("got note %v, want second", c["note"])
+	}
+
+	for _, idx := range []int{0, 3} {
+		if _, err := commentAt(doc, idx); err == nil {
+			t.Errorf("expected error for index %d", idx)
+		}
+	}
+}
+
+func TestRemoveUnreferencedAttachments(t *testing.T) {
+	doc := map[string]interface{}{
+		"comments": []interface{}{
+			map[string]interface{}{"note": "still here", "attachments": []interface{}{"shared.jpg"}},
+		},
+		"_attachments": map[string]interface{}{
+			"shared.jpg":         map[string]interface{}{},
+			"shared.256x192.jpg": map[string]interface{}{},
+			"own.jpg":            map[string]interface{}{},
+			"own.256x192.jpg":    map[string]interface{}{},
+			"other.pdf":          map[string]interface{}{},
+		},
+	}
+
+	removeUnreferencedAttachments(doc, []string{"shared.jpg", "own.jpg"})
+
+	var got []string
+	for name := range doc["_attachments"].(map[string]interface{}) {
+		got = append(got, name)
+	}
+	sort.Strings(got)
+	if want := []string{"other.pdf", "shared.256x192.jpg", "shared.jpg"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("attachments = %v, want %v", got, want)
+	}
+}
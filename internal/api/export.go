@@ -0,0 +1,143 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// AnswerColumn is a question column of an answer matrix (one row per audit)
+type AnswerColumn struct {
+	Header   string // "Category / Question"
+	Category string
+	Question string
+}
+
+// AnswerColumnHeader returns the header of a question column
+func AnswerColumnHeader(category, question string) string {
+	return strings.TrimSpace(category) + " / " + strings.TrimSpace(question)
+}
+
+// AnswerColumns returns a column for every answerable question of the audits, in
+// the order they first appear. Repeated questions get a " (2)", " (3)"... suffix.
+func AnswerColumns(audits []*Audit) []AnswerColumn {
+	var columns []AnswerColumn
+	seen := make(map[string]bool)
+	for _, audit := range audits {
+		for _, key := range answerKeys(audit) {
+			if seen[key.Header] {
+				continue
+			}
+			seen[key.Header] = true
+			columns = append(columns, key)
+		}
+	}
+	return columns
+}
+
+// MatrixAnswers returns the answers of an audit for the columns: float64 for numeric
+// and rating questions, option texts separated by ";" for multiple choice, the
+// readable answer text otherwise, and "" when the audit has no such question or it
+// isn't answered
+func MatrixAnswers(audit *Audit, columns []AnswerColumn) []interface{} {
+	questions := auditQuestions(audit)
+	answers := make(map[string]Question, len(questions))
+	for i, key := range answerKeys(audit) {
+		answers[key.Header] = questions[i].question
+	}
+
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		q, ok := answers[column.Header]
+		if !ok || !IsAnswered(q.Answer) {
+			values[i] = ""
+			continue
+		}
+		answerType := questionAnswerType(q)
+		if answerType == "numeric" || answerType == "rating" {
+			if v, ok := numericAnswer(q.Answer); ok {
+				values[i] = v
+				continue
+			}
+		}
+		if answerType == "multiplechoice" {
+			// Separated like the answer import expects them
+			var choices []string
+			for _, a := range q.Answer {
+				if id, ok := a.(string); ok && id != "" {
+					choices = append(choices, optionText(q.Settings, id))
+				}
+			}
+			values[i] = strings.Join(choices, ";")
+			continue
+		}
+		values[i] = AnswerText(q.Settings, q.Answer)
+	}
+	return values
+}
+
+// answerKeys returns the columns of the answerable questions of one audit, in the
+// order of auditQuestions
+func answerKeys(audit *Audit) []AnswerColumn {
+	var keys []AnswerColumn
+	count := make(map[string]int)
+	for _, q := range auditQuestions(audit) {
+		header := AnswerColumnHeader(q.category, q.question.Question)
+		count[strings.ToLower(header)]++
+		n := count[strings.ToLower(header)]
+		if n > 1 {
+			header = fmt.Sprintf("%s (%d)", header, n)
+		}
+		keys = append(keys, AnswerColumn{Header: header, Category: q.category, Question: q.question.Question})
+	}
+	return keys
+}
+
+// GetAudits fetches audits concurrently with the given number of workers. The
+// result has the same order as ids; the first error stops the remaining requests.
+func (c *Client) GetAudits(database string, ids []string, workers int) ([]*Audit, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	audits := make([]*Audit, len(ids))
+	jobs := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				audit, err := c.GetAudit(database, ids[i])
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("getting audit %s: %w", ids[i], err)
+				}
+				mu.Unlock()
+				audits[i] = audit
+			}
+		}()
+	}
+
+	for i := range ids {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return audits, nil
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnswerMatrix(t *testing.T) {
+	yesno := &QuestionSettings{AnswerType: "yesnona"}
+	rating := &QuestionSettings{AnswerType: "rating"}
+	first := &Audit{Questions: []QuestionCategory{
+		{CategoryName: "Fire safety", Questions: []Question{
+			{Question: "Exits free?", Settings: yesno, Answer: []interface{}{"no"}},
+			{Question: "Instructions", Settings: &QuestionSettings{AnswerType: "statictext"}},
+			{Question: "Exits free?", Settings: yesno, Answer: []interface{}{"yes"}},
+		}},
+		{CategoryName: "Housekeeping", Questions: []Question{
+			{Question: "Tidiness", Settings: rating, Answer: []interface{}{4.0}},
+		}},
+	}}
+	second := &Audit{Questions: []QuestionCategory{
+		{CategoryName: "Fire safety", Questions: []Question{
+			{Question: "Exits free?", Settings: yesno},
+			{Question: "Alarm tested?", Settings: yesno, Answer: []interface{}{"na"}},
+		}},
+	}}
+
+	columns := AnswerColumns([]*Audit{first, second})
+	var headers []string
+	for _, c := range columns {
+		headers = append(headers, c.Header)
+	}
+	want := []string{"Fire safety / Exits free?", "Fire safety / Exits free? (2)", "Housekeeping / Tidiness", "Fire safety / Alarm tested?"}
+	if !reflect.DeepEqual(headers, want) {
+		t.Fatalf("headers = %v, want %v", headers, want)
+	}
+
+	if got, want := MatrixAnswers(first, columns), []interface{}{"No", "Yes", 4.0, ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first = %v, want %v", got, want)
+	}
+	if got, want := MatrixAnswers(second, columns), []interface{}{"", "", "", "N.A."}; !reflect.DeepEqual(got, want) {
+		t.Errorf("second = %v, want %v", got, want)
+	}
+}
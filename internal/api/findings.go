@@ -0,0 +1,199 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultRatingThreshold is the rating below which an answer is a finding
+const DefaultRatingThreshold = 3
+
+// Finding is an audit answer that needs follow-up: a "no" answer, or a rating
+// below the threshold
+type Finding struct {
+	Category       string   `json:"category"`
+	Number         int      `json:"number"` // 1-based position of the question in its category
+	Question       string   `json:"question"`
+	Description    string   `json:"description,omitempty"`
+	Answer         string   `json:"answer"`
+	Comments       []string `json:"comments,omitempty"`
+	Photos         []string `json:"photos,omitempty"` // Attachment names on the audit
+	TicketRequired bool     `json:"ticketRequired"`
+	Tickets        []string `json:"tickets"` // IDs of linked tickets
+	Property       string   `json:"property"`
+	categoryIndex  int
+	questionIndex  int
+}
+
+// Linked reports whether a ticket is already linked to the finding
+func (f Finding) Linked() bool {
+	return len(f.Tickets) > 0
+}
+
+// FindAuditFindings returns the findings of an audit document. Ratings below
+// ratingThreshold count as findings; a threshold of 0 leaves ratings out.
+func FindAuditFindings(doc map[string]interface{}, ratingThreshold float64) []Finding {
+	var findings []Finding
+	categories, _ := doc["questions"].([]interface{})
+	for ci, c := range categories {
+		category, _ := c.(map[string]interface{})
+		categoryName, _ := category["categoryName"].(string)
+		questions, _ := category["questions"].([]interface{})
+		for qi, q := range questions {
+			question, ok := q.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			settings := questionSettings(question)
+			answer, _ := question["answer"].([]interface{})
+			if !isFinding(settings.AnswerType, answer, ratingThreshold) {
+				continue
+			}
+
+			text, _ := question["question"].(string)
+			description, _ := question["description"].(string)
+			findings = append(findings, Finding{
+				Category:    categoryName,
+				Number:      qi + 1,
+				Question:    text,
+				Description: description,
+				Answer: AnswerText(&QuestionSettings{
+					AnswerType:  settings.AnswerType,
+					RichOptions: settings.RichOptions,
+					Styling:     settings.Styling,
+				}, answer),
+				Comments:       questionComments(question),
+				Photos:         questionPhotos(question),
+				TicketRequired: settings.TicketRequired,
+				Tickets:        linkedTickets(question),
+				Property:       fmt.Sprintf("questions.%d.questions.%d", ci, qi),
+				categoryIndex:  ci,
+				questionIndex:  qi,
+			})
+		}
+	}
+	return findings
+}
+
+// isFinding reports whether an answer is negative
+func isFinding(answerType string, answer []interface{}, ratingThreshold float64) bool {
+	switch answerType {
+	case "yesnona":
+		return yesNoValue(answer) == "no"
+	case "rating":
+		value, ok := numericAnswer(answer)
+		return ok && ratingThreshold > 0 && value < ratingThreshold
+	}
+	return false
+}
+
+// questionComments returns the comments on an audit question. Comments are stored
+// like ticket comments (objects with a note) or as plain strings.
+func questionComments(question map[string]interface{}) []string {
+	var comments []string
+	raw, _ := question["comments"].([]interface{})
+	for _, c := range raw {
+		switch v := c.(type) {
+		case string:
+			if v != "" {
+				comments = append(comments, v)
+			}
+		case map[string]interface{}:
+			if note, _ := v["note"].(string); note != "" {
+				comments = append(comments, note)
+			}
+		}
+	}
+	if remark, _ := question["remark"].(string); remark != "" {
+		comments = append(comments, remark)
+	}
+	return comments
+}
+
+// questionPhotos returns the names of the audit attachments referenced by a question
+func questionPhotos(question map[string]interface{}) []string {
+	var names []string
+	raw, _ := question["attachments"].([]interface{})
+	for _, a := range raw {
+		switch v := a.(type) {
+		case string:
+			names = append(names, v)
+		case map[string]interface{}:
+			if name, _ := v["name"].(string); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// linkedTickets returns the IDs in a question's ticket array. Entries are ticket IDs
+// or objects with an id.
+func linkedTickets(question map[string]interface{}) []string {
+	ids := []string{}
+	raw, _ := question["ticket"].([]interface{})
+	for _, t := range raw {
+		switch v := t.(type) {
+		case string:
+			if v != "" {
+				ids = append(ids, v)
+			}
+		case map[string]interface{}:
+			for _, key := range []string{"id", "_id", "couchDbId"} {
+				if id, _ := v[key].(string); id != "" {
+					ids = append(ids, id)
+					break
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// LinkFindingTicket adds a ticket to the ticket array of a finding's question and
+// records an operation. It fails if the question moved since the finding was read.
+func (c *Client) LinkFindingTicket(database, auditID string, finding Finding, ticketID string) error {
+	doc, err := c.GetDocument(database, auditID)
+	if err != nil {
+		return fmt.Errorf("getting audit: %w", err)
+	}
+
+	email, err := c.Email()
+	if err != nil {
+		return fmt.Errorf("getting user email: %w", err)
+	}
+
+	oldTickets, err := linkFindingTicket(doc, finding, ticketID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	markModified(doc, email, now)
+	appendOperation(doc, email, now, []string{finding.Property + ".ticket"}, []interface{}{oldTickets}, []interface{}{ticketID})
+
+	return c.UpdateDocument(database, auditID, doc)
+}
+
+// linkFindingTicket appends ticketID to the question's ticket array and returns the old array
+func linkFindingTicket(doc map[string]interface{}, finding Finding, ticketID string) ([]interface{}, error) {
+	categories, _ := doc["questions"].([]interface{})
+	if finding.categoryIndex >= len(categories) {
+		return nil, fmt.Errorf("question %q not found in audit", finding.Question)
+	}
+	category, _ := categories[finding.categoryIndex].(map[string]interface{})
+	questions, _ := category["questions"].([]interface{})
+	if finding.questionIndex >= len(questions) {
+		return nil, fmt.Errorf("question %q not found in audit", finding.Question)
+	}
+	question, _ := questions[finding.questionIndex].(map[string]interface{})
+	if text, _ := question["question"].(string); !strings.EqualFold(text, finding.Question) {
+		return nil, fmt.Errorf("question %q not found in audit (the audit changed)", finding.Question)
+	}
+
+	oldTickets, _ := question["ticket"].([]interface{})
+	tickets := append([]interface{}{}, oldTickets...)
+	question["ticket"] = append(tickets, ticketID)
+	return oldTickets, nil
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func findingsDoc() map[string]interface{} {
+	yesno := map[string]interface{}{"answertype": "yesnona", "ticketRequired": true}
+	rating := map[string]interface{}{"answertype": "rating"}
+	return map[string]interface{}{
+		"name": "Site inspection",
+		"questions": []interface{}{
+			map[string]interface{}{
+				"categoryName": "Fire safety",
+				"questions": []interface{}{
+					map[string]interface{}{"question": "Extinguisher present?", "answer": []interface{}{"yes"}, "settings": yesno},
+					map[string]interface{}{
+						"question":    "Emergency exits free?",
+						"answer":      []interface{}{"no"},
+						"settings":    yesno,
+						"comments":    []interface{}{map[string]interface{}{"note": "Pallets in front of exit B"}},
+						"attachments": []interface{}{"exit-b.jpg"},
+					},
+					map[string]interface{}{"question": "Alarm tested?", "answer": []interface{}{"no"}, "settings": yesno, "ticket": []interface{}{"ticket-1"}},
+				},
+			},
+			map[string]interface{}{
+				"categoryName": "Housekeeping",
+				"questions": []interface{}{
+					map[string]interface{}{"question": "Tidiness", "answer": []interface{}{2.0}, "settings": rating},
+					map[string]interface{}{"question": "Lighting", "answer": []interface{}{4.0}, "settings": rating},
+					map[string]interface{}{"question": "Remarks", "answer": []interface{}{"no"}, "settings": map[string]interface{}{"answertype": "freetext"}},
+				},
+			},
+		},
+	}
+}
+
+func TestFindAuditFindings(t *testing.T) {
+	findings := FindAuditFindings(findingsDoc(), DefaultRatingThreshold)
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Property+" "+f.Answer)
+	}
+	want := []string{"questions.0.questions.1 No", "questions.0.questions.2 No", "questions.1.questions.0 2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+
+	exits := findings[0]
+	if exits.Number != 2 || !exits.TicketRequired || exits.Linked() {
+		t.Errorf("finding = %+v", exits)
+	}
+	if !reflect.DeepEqual(exits.Comments, []string{"Pallets in front of exit B"}) || !reflect.DeepEqual(exits.Photos, []string{"exit-b.jpg"}) {
+		t.Errorf("comments = %v, photos = %v", exits.Comments, exits.Photos)
+	}
+	if !findings[1].Linked() {
+		t.Error("finding with a ticket is not linked")
+	}
+
+	if n := len(FindAuditFindings(findingsDoc(), 0)); n != 2 {
+		t.Errorf("findings without rating threshold = %d, want 2", n)
+	}
+}
+
+func TestLinkFindingTicket(t *testing.T) {
+	doc := findingsDoc()
+	findings := FindAuditFindings(doc, DefaultRatingThreshold)
+
+	if _, err := linkFindingTicket(doc, findings[1], "ticket-2"); err != nil {
+		t.Fatal(err)
+	}
+	again := FindAuditFindings(doc, DefaultRatingThreshold)
+	if !reflect.DeepEqual(again[1].Tickets, []string{"ticket-1", "ticket-2"}) {
+		t.Errorf("tickets = %v", again[1].Tickets)
+	}
+
+	// The question moved since the findings were read
+	categories := doc["questions"].([]interface{})
+	questions := categories[0].(map[string]interface{})["questions"].([]interface{})
+	questions[1], questions[2] = questions[2], questions[1]
+	if _, err := linkFindingTicket(doc, findings[0], "ticket-3"); err == nil {
+		t.Error("expected an error for a moved question")
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ImportAuditAnswers sets the answers of an answer-matrix row on an audit and
+// records an operation. See ApplyMatrixAnswers for the columns and values.
+func (c *Client) ImportAuditAnswers(database, auditID string, columns, values []string) ([]AnswerResult, error) {
+	doc, err := c.GetDocument(database, auditID)
+	if err != nil {
+		return nil, fmt.Errorf("getting audit: %w", err)
+	}
+
+	results, err := ApplyMatrixAnswers(doc, columns, values)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	if err := c.saveAnswers(database, auditID, doc, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ApplyMatrixAnswers sets answers on the questions of an audit (or template)
+// document. columns are question column headers as written by an answer-matrix
+// export ("Category / Question", matched case-insensitively) and values the
+// answers in the same order; empty values are skipped. Every value is validated
+// and the returned error lists all problems.
+func ApplyMatrixAnswers(doc map[string]interface{}, columns, values []string) ([]AnswerResult, error) {
+	categories, _ := doc["questions"].([]interface{})
+	if len(categories) == 0 {
+		return nil, fmt.Errorf("audit has no questions")
+	}
+
+	positions, err := matrixPositions(categories)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []string
+	var results []AnswerResult
+	for i, column := range columns {
+		value := ""
+		if i < len(values) {
+			value = strings.TrimSpace(values[i])
+		}
+		if value == "" {
+			continue
+		}
+
+		pos, ok := positions[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("column %q: question not found", column))
+			continue
+		}
+
+		cat, _ := categories[pos.categoryIndex].(map[string]interface{})
+		questions, _ := cat["questions"].([]interface{})
+		question, ok := questions[pos.questionIndex].(map[string]interface{})
+		if !ok {
+			errs = append(errs, fmt.Sprintf("column %q: question is malformed", column))
+			continue
+		}
+
+		answer, err := parseAnswer(questionSettings(question), []string{value})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("column %q: %v", column, err))
+			continue
+		}
+
+		oldAnswer, _ := question["answer"].([]interface{})
+		question["answer"] = answer
+
+		categoryName, _ := cat["categoryName"].(string)
+		questionText, _ := question["question"].(string)
+		results = append(results, AnswerResult{
+			Category:  categoryName,
+			Question:  questionText,
+			Property:  fmt.Sprintf("questions.%d.questions.%d.answer", pos.categoryIndex, pos.questionIndex),
+			OldAnswer: oldAnswer,
+			Answer:    answer,
+		})
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid answers:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+	return results, nil
+}
+
+// matrixPositions maps the lowercased column headers of a document's questions to
+// their positions, using the same headers as answerKeys
+func matrixPositions(categories []interface{}) (map[string]diffQuestion, error) {
+	data, err := json.Marshal(categories)
+	if err != nil {
+		return nil, fmt.Errorf("reading questions: %w", err)
+	}
+	var audit Audit
+	if err := json.Unmarshal(data, &audit.Questions); err != nil {
+		return nil, fmt.Errorf("reading questions: %w", err)
+	}
+
+	questions := auditQuestions(&audit)
+	positions := make(map[string]diffQuestion, len(questions))
+	for i, key := range answerKeys(&audit) {
+		positions[strings.ToLower(key.Header)] = questions[i]
+	}
+	return positions, nil
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func matrixDoc() map[string]interface{} {
+	yesno := map[string]interface{}{
+		"answertype": "yesnona",
+		"styling": map[string]interface{}{"options": map[string]interface{}{
+			"yes": map[string]interface{}{"label": "OK"},
+			"no":  map[string]interface{}{"label": "Not OK"},
+		}},
+	}
+	return map[string]interface{}{
+		"questions": []interface{}{
+			map[string]interface{}{"categoryName": "Fire safety", "questions": []interface{}{
+				map[string]interface{}{"question": "Exits free?", "settings": yesno, "answer": []interface{}{"yes"}},
+				map[string]interface{}{"question": "Instructions", "settings": map[string]interface{}{"answertype": "statictext"}},
+				map[string]interface{}{"question": "Exits free?", "settings": yesno},
+			}},
+			map[string]interface{}{"categoryName": "Housekeeping", "questions": []interface{}{
+				map[string]interface{}{"question": "Tidiness", "settings": map[string]interface{}{"answertype": "rating"}},
+				map[string]interface{}{"question": "Issues", "settings": map[string]interface{}{
+					"answertype": "multiplechoice",
+					"choice":     "multiple",
+					"richOptions": []interface{}{
+						map[string]interface{}{"id": "a1", "text": "Dust"},
+						map[string]interface{}{"id": "a2", "text": "Clutter"},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+func TestApplyMatrixAnswers(t *testing.T) {
+	doc := matrixDoc()
+	columns := []string{"Fire safety / Exits free?", "fire safety / exits free? (2)", "Housekeeping / Tidiness", "Housekeeping / Issues"}
+
+	results, err := ApplyMatrixAnswers(doc, columns, []string{"Not OK", "", "4", "Dust;Clutter"})
+	if err != nil {
+		t.Fatalf("ApplyMatrixAnswers: %v", err)
+	}
+
+	var props []string
+	for _, r := range results {
+		props = append(props, r.Property)
+	}
+	want := []string{"questions.0.questions.0.answer", "questions.1.questions.0.answer", "questions.1.questions.1.answer"}
+	if !reflect.DeepEqual(props, want) {
+		t.Fatalf("properties = %v, want %v", props, want)
+	}
+	if got := results[0].OldAnswer; !reflect.DeepEqual(got, []interface{}{"yes"}) {
+		t.Errorf("old answer = %v", got)
+	}
+
+	categories := doc["questions"].([]interface{})
+	fire := categories[0].(map[string]interface{})["questions"].([]interface{})
+	if got := fire[0].(map[string]interface{})["answer"]; !reflect.DeepEqual(got, []interface{}{"no"}) {
+		t.Errorf("exits answer = %v, want [no]", got)
+	}
+	if _, ok := fire[2].(map[string]interface{})["answer"]; ok {
+		t.Error("empty value should leave the question unanswered")
+	}
+	house := categories[1].(map[string]interface{})["questions"].([]interface{})
+	if got := house[1].(map[string]interface{})["answer"]; !reflect.DeepEqual(got, []interface{}{"a1", "a2"}) {
+		t.Errorf("issues answer = %v, want [a1 a2]", got)
+	}
+}
+
+func TestApplyMatrixAnswersErrors(t *testing.T) {
+	columns := []string{"Fire safety / Exits free?", "Housekeeping / Tidiness", "Housekeeping / Lighting", "Housekeeping / Issues"}
+	_, err := ApplyMatrixAnswers(matrixDoc(), columns, []string{"maybe", "9", "ok", "Dust"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{`"Fire safety / Exits free?"`, `"Housekeeping / Tidiness"`, `"Housekeeping / Lighting": question not found`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "Issues") {
+		t.Errorf("valid column reported: %v", err)
+	}
+}
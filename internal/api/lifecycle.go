@@ -0,0 +1,367 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Ticket states as stored in state.state
+const (
+	TicketCreated   = "created"
+	TicketStarted   = "started"
+	TicketCompleted = "completed"
+)
+
+// ticketEvent is a lifecycle change requested for a ticket
+type ticketEvent string
+
+const (
+	eventAssign   ticketEvent = "assign"
+	eventUnassign ticketEvent = "unassign"
+	eventComplete ticketEvent = "complete"
+	eventReopen   ticketEvent = "reopen"
+)
+
+// nextTicketState returns the state a ticket moves to when event happens.
+// The lifecycle is created → started (assigned) → completed, and completed tickets
+// can be reopened, which returns them to started (or created without a responsible).
+// hasResponsible tells whether the ticket has a responsible after the event.
+func nextTicketState(current string, event ticketEvent, hasResponsible bool) (string, error) {
+	current = normalizeTicketState(current, hasResponsible)
+
+	switch event {
+	case eventAssign:
+		if current == TicketCompleted {
+			return "", fmt.Errorf("ticket is completed, reopen it first")
+		}
+		return TicketStarted, nil
+	case eventUnassign:
+		if current == TicketCompleted {
+			return "", fmt.Errorf("ticket is completed, reopen it first")
+		}
+		return TicketCreated, nil
+	case eventComplete:
+		if current == TicketCompleted {
+			return "", fmt.Errorf("ticket is already completed")
+		}
+		return TicketCompleted, nil
+	case eventReopen:
+		if current != TicketCompleted {
+			return "", fmt.Errorf("ticket is not completed (status: %s)", current)
+		}
+		if hasResponsible {
+			return TicketStarted, nil
+		}
+		return TicketCreated, nil
+	}
+	return "", fmt.Errorf("unknown ticket event %q", event)
+}
+
+// normalizeTicketState maps missing or unknown states onto the lifecycle
+func normalizeTicketState(state string, hasResponsible bool) string {
+	switch state {
+	case TicketCreated, TicketStarted, TicketCompleted:
+		return state
+	}
+	if hasResponsible {
+		return TicketStarted
+	}
+	return TicketCreated
+}
+
+// ticketLifecycleEvents returns the events requested by update options, in the order
+// they are applied: reopen first, then responsible changes, then completion.
+func ticketLifecycleEvents(opts UpdateTicketFieldsOptions) ([]ticketEvent, error) {
+	if opts.Responsible != nil && opts.ClearResponsible {
+		return nil, fmt.Errorf("cannot set and clear the responsible at the same time")
+	}
+	if opts.ClearResponsible && opts.Complete {
+		return nil, fmt.Errorf("cannot complete a ticket without a responsible")
+	}
+	if opts.Reopen && opts.Complete {
+		return nil, fmt.Errorf("cannot reopen and complete a ticket at the same time")
+	}
+
+	var events []ticketEvent
+	if opts.Reopen {
+		events = append(events, eventReopen)
+	}
+	if opts.Responsible != nil {
+		events = append(events, eventAssign)
+	}
+	if opts.ClearResponsible {
+		events = append(events, eventUnassign)
+	}
+	if opts.Complete {
+		events = append(events, eventComplete)
+	}
+	return events, nil
+}
+
+// applyTicketLifecycle applies the responsible and status changes of opts to a ticket
+// document. Completing a ticket without a responsible assigns the current user.
+// Returns the changed properties with their old and new values for the operation record.
+func applyTicketLifecycle(doc map[string]interface{}, email, now string, opts UpdateTicketFieldsOptions) ([]string, []interface{}, []interface{}, error) {
+	events, err := ticketLifecycleEvents(opts)
+	if err != nil || len(events) == 0 {
+		return nil, nil, nil, err
+	}
+
+	oldResponsible := documentResponsible(doc)
+	oldStatus := ""
+	if state, ok := doc["state"].(map[string]interface{}); ok {
+		oldStatus, _ = state["state"].(string)
+	}
+
+	responsible := oldResponsible
+	status := oldStatus
+	for _, event := range events {
+		switch event {
+		case eventAssign:
+			responsible = *opts.Responsible
+		case eventUnassign:
+			responsible = ""
+		case eventComplete:
+			if responsible == "" {
+				responsible = email
+			}
+		}
+		status, err = nextTicketState(status, event, responsible != "")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	var changedProps []string
+	var oldValues, newValues []interface{}
+
+	if status != oldStatus {
+		setTicketState(doc, status)
+		if status == TicketCompleted {
+			setDocumentDate(doc, "completionDate", now)
+		} else if oldStatus == TicketCompleted {
+			setDocumentDate(doc, "completionDate", "")
+		}
+		changedProps = append(changedProps, "status")
+		oldValues = append(oldValues, oldStatus)
+		newValues = append(newValues, status)
+	}
+
+	if responsible != oldResponsible {
+		setDocumentResponsible(doc, responsible)
+		changedProps = append(changedProps, "responsible")
+		oldValues = append(oldValues, oldResponsible)
+		newValues = append(newValues, responsible)
+	}
+
+	return changedProps, oldValues, newValues, nil
+}
+
+// documentResponsible returns the email of the responsible person of a ticket or audit
+func documentResponsible(doc map[string]interface{}) string {
+	participants, _ := doc["participants"].(map[string]interface{})
+	switch resp := participants["responsible"].(type) {
+	case map[string]interface{}:
+		email, _ := resp["email"].(string)
+		return email
+	case string:
+		// Written by older CLI versions
+		return resp
+	}
+	return ""
+}
+
+// setDocumentResponsible sets (or with an empty email, removes) the responsible person
+func setDocumentResponsible(doc map[string]interface{}, email string) {
+	participants, ok := doc["participants"].(map[string]interface{})
+	if !ok {
+		if email == "" {
+			return
+		}
+		participants = map[string]interface{}{
+			"type":      "IB.EdBundle.Document.Participants",
+			"consulted": []interface{}{},
+			"informed":  []interface{}{},
+		}
+		doc["participants"] = participants
+	}
+
+	if email == "" {
+		delete(participants, "responsible")
+		return
+	}
+	participants["responsible"] = map[string]interface{}{
+		"type":  "IB.EdBundle.Document.Person",
+		"email": email,
+	}
+}
+
+// setTicketState sets state.state
+func setTicketState(doc map[string]interface{}, status string) {
+	if state, ok := doc["state"].(map[string]interface{}); ok {
+		state["state"] = status
+		return
+	}
+	doc["state"] = map[string]interface{}{
+		"type":  "IB.EdBundle.Document.State",
+		"state": status,
+	}
+}
+
+// setDocumentDate sets (or with an empty value, removes) a field in dates
+func setDocumentDate(doc map[string]interface{}, field, value string) {
+	dates, ok := doc["dates"].(map[string]interface{})
+	if !ok {
+		if value == "" {
+			return
+		}
+		dates = map[string]interface{}{}
+		doc["dates"] = dates
+	}
+	if value == "" {
+		delete(dates, field)
+		return
+	}
+	dates[field] = value
+}
+
+// Audit statuses as stored in status. "started" and "In Progress" are both active;
+// newer audits start as "started".
+const (
+	AuditStarted    = "started"
+	AuditInProgress = "In Progress"
+	AuditCompleted  = "completed"
+)
+
+// AuditTransition is a status change requested for an audit
+type AuditTransition string
+
+const (
+	AuditStart    AuditTransition = "start"
+	AuditComplete AuditTransition = "complete"
+	AuditReopen   AuditTransition = "reopen"
+)
+
+// UnansweredError is returned when an audit can't be completed because required
+// questions have no answer
+type UnansweredError struct {
+	Questions []string // "Category / 3. Question"
+}
+
+func (e *UnansweredError) Error() string {
+	return fmt.Sprintf("%d required question(s) not answered:\n  %s", len(e.Questions), strings.Join(e.Questions, "\n  "))
+}
+
+// AuditStatusChange describes the result of an audit status transition
+type AuditStatusChange struct {
+	OldStatus      string `json:"oldStatus"`
+	Status         string `json:"status"`
+	CompletionDate string `json:"completionDate,omitempty"`
+}
+
+// nextAuditStatus returns the status an audit moves to on a transition. Active
+// audits (started or In Progress) can be completed; start marks a started audit as
+// In Progress, and reopen returns a completed audit to In Progress.
+func nextAuditStatus(current string, transition AuditTransition) (string, error) {
+	switch transition {
+	case AuditStart:
+		switch current {
+		case AuditCompleted:
+			return "", fmt.Errorf("audit is completed, reopen it first")
+		case AuditInProgress:
+			return "", fmt.Errorf("audit is already in progress")
+		}
+		return AuditInProgress, nil
+	case AuditComplete:
+		if current == AuditCompleted {
+			return "", fmt.Errorf("audit is already completed")
+		}
+		return AuditCompleted, nil
+	case AuditReopen:
+		if current != AuditCompleted {
+			return "", fmt.Errorf("audit is not completed (status: %s)", current)
+		}
+		return AuditInProgress, nil
+	}
+	return "", fmt.Errorf("unknown audit transition %q", transition)
+}
+
+// TransitionAudit changes the status of an audit and records the change in the
+// operation log. Completing checks that all required questions are answered unless
+// force is set; it sets dates.completionDate, and reopening removes it.
+func (c *Client) TransitionAudit(database, auditID string, transition AuditTransition, force bool) (*AuditStatusChange, error) {
+	doc, err := c.GetDocument(database, auditID)
+	if err != nil {
+		return nil, fmt.Errorf("getting audit: %w", err)
+	}
+
+	email, err := c.Email()
+	if err != nil {
+		return nil, fmt.Errorf("getting user email: %w", err)
+	}
+
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	change, err := applyAuditTransition(doc, now, transition, force)
+	if err != nil {
+		return nil, err
+	}
+
+	markModified(doc, email, now)
+	appendOperation(doc, email, now, []string{"status"}, []interface{}{change.OldStatus}, []interface{}{change.Status})
+
+	if err := c.UpdateDocument(database, auditID, doc); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// applyAuditTransition sets the new status and completion date on an audit document
+func applyAuditTransition(doc map[string]interface{}, now string, transition AuditTransition, force bool) (*AuditStatusChange, error) {
+	oldStatus, _ := doc["status"].(string)
+	status, err := nextAuditStatus(oldStatus, transition)
+	if err != nil {
+		return nil, err
+	}
+
+	if transition == AuditComplete && !force {
+		if missing := unansweredRequired(doc); len(missing) > 0 {
+			return nil, &UnansweredError{Questions: missing}
+		}
+	}
+
+	doc["status"] = status
+	change := &AuditStatusChange{OldStatus: oldStatus, Status: status}
+	switch {
+	case status == AuditCompleted:
+		setDocumentDate(doc, "completionDate", now)
+		change.CompletionDate = now
+	case oldStatus == AuditCompleted:
+		setDocumentDate(doc, "completionDate", "")
+	}
+	return change, nil
+}
+
+// unansweredRequired lists the required questions of an audit document without an answer
+func unansweredRequired(doc map[string]interface{}) []string {
+	var missing []string
+	categories, _ := doc["questions"].([]interface{})
+	for _, c := range categories {
+		category, _ := c.(map[string]interface{})
+		categoryName, _ := category["categoryName"].(string)
+		questions, _ := category["questions"].([]interface{})
+		for i, q := range questions {
+			question, _ := q.(map[string]interface{})
+			settings, _ := question["settings"].(map[string]interface{})
+			if required, _ := settings["required"].(bool); !required {
+				continue
+			}
+			answer, _ := question["answer"].([]interface{})
+			if !IsAnswered(answer) {
+				text, _ := question["question"].(string)
+				missing = append(missing, fmt.Sprintf("%s / %d. %s", categoryName, i+1, text))
+			}
+		}
+	}
+	return missing
+}
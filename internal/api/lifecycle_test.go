@@ -0,0 +1,242 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNextTicketState(t *testing.T) {
+	tests := []struct {
+		name           string
+		current        string
+		event          ticketEvent
+		hasResponsible bool
+		want           string
+		wantErr        bool
+	}{
+		{name: "assign new ticket", current: TicketCreated, event: eventAssign, hasResponsible: true, want: TicketStarted},
+		{name: "reassign", current: TicketStarted, event: eventAssign, hasResponsible: true, want: TicketStarted},
+		{name: "assign completed", current: TicketCompleted, event: eventAssign, hasResponsible: true, wantErr: true},
+		{name: "unassign", current: TicketStarted, event: eventUnassign, want: TicketCreated},
+		{name: "complete started", current: TicketStarted, event: eventComplete, hasResponsible: true, want: TicketCompleted},
+		{name: "complete new ticket", current: TicketCreated, event: eventComplete, hasResponsible: true, want: TicketCompleted},
+		{name: "complete twice", current: TicketCompleted, event: eventComplete, hasResponsible: true, wantErr: true},
+		{name: "reopen with responsible", current: TicketCompleted, event: eventReopen, hasResponsible: true, want: TicketStarted},
+		{name: "reopen without responsible", current: TicketCompleted, event: eventReopen, want: TicketCreated},
+		{name: "reopen open ticket", current: TicketStarted, event: eventReopen, hasResponsible: true, wantErr: true},
+		{name: "missing state", current: "", event: eventComplete, hasResponsible: true, want: TicketCompleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextTicketState(tt.current, tt.event, tt.hasResponsible)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nextTicketState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("nextTicketState() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func ticketDoc(state, responsible string) map[string]interface{} {
+	doc := map[string]interface{}{
+		"state": map[string]interface{}{"type": "IB.EdBundle.Document.State", "state": state},
+		"dates": map[string]interface{}{"creationDate": "2026-03-01T10:00:00.000Z"},
+	}
+	if responsible != "" {
+		doc["participants"] = map[string]interface{}{
+			"responsible": map[string]interface{}{"type": "IB.EdBundle.Document.Person", "email": responsible},
+		}
+	}
+	return doc
+}
+
+func TestApplyTicketLifecycle(t *testing.T) {
+	const now = "2026-03-05T12:00:00.000Z"
+	assignee := "jane@example.com"
+
+	t.Run("close sets completion date", func(t *testing.T) {
+		doc := ticketDoc(TicketStarted, assignee)
+		props, oldVals, newVals, err := applyTicketLifecycle(doc, "me@example.com", now, UpdateTicketFieldsOptions{Complete: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(props, []string{"status"}) || oldVals[0] != TicketStarted || newVals[0] != TicketCompleted {
+			t.Errorf("changes = %v %v %v", props, oldVals, newVals)
+		}
+		if got := doc["dates"].(map[string]interface{})["completionDate"]; got != now {
+			t.Errorf("completionDate = %v", got)
+		}
+		if _, ok := doc["status"]; ok {
+			t.Error("top-level status field was written")
+		}
+	})
+
+	t.Run("close without responsible assigns current user", func(t *testing.T) {
+		doc := ticketDoc(TicketCreated, "")
+		props, _, _, err := applyTicketLifecycle(doc, "me@example.com", now, UpdateTicketFieldsOptions{Complete: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(props, []string{"status", "responsible"}) {
+			t.Errorf("changed properties = %v", props)
+		}
+		if got := documentResponsible(doc); got != "me@example.com" {
+			t.Errorf("responsible = %q", got)
+		}
+	})
+
+	t.Run("reopen clears completion date", func(t *testing.T) {
+		doc := ticketDoc(TicketCompleted, assignee)
+		doc["dates"].(map[string]interface{})["completionDate"] = "2026-03-04T10:00:00.000Z"
+		if _, _, _, err := applyTicketLifecycle(doc, "me@example.com", now, UpdateTicketFieldsOptions{Reopen: true}); err != nil {
+			t.Fatal(err)
+		}
+		if got := doc["state"].(map[string]interface{})["state"]; got != TicketStarted {
+			t.Errorf("state = %v", got)
+		}
+		if _, ok := doc["dates"].(map[string]interface{})["completionDate"]; ok {
+			t.Error("completionDate was not removed")
+		}
+	})
+
+	t.Run("assign writes a typed person", func(t *testing.T) {
+		doc := ticketDoc(TicketCreated, "")
+		if _, _, _, err := applyTicketLifecycle(doc, "me@example.com", now, UpdateTicketFieldsOptions{Responsible: &assignee}); err != nil {
+			t.Fatal(err)
+		}
+		resp := doc["participants"].(map[string]interface{})["responsible"]
+		want := map[string]interface{}{"type": "IB.EdBundle.Document.Person", "email": assignee}
+		if !reflect.DeepEqual(resp, want) {
+			t.Errorf("responsible = %v", resp)
+		}
+	})
+
+	t.Run("assign completed ticket is rejected", func(t *testing.T) {
+		doc := ticketDoc(TicketCompleted, assignee)
+		other := "john@example.com"
+		if _, _, _, err := applyTicketLifecycle(doc, "me@example.com", now, UpdateTicketFieldsOptions{Responsible: &other}); err == nil {
+			t.Error("expected an error")
+		}
+		if got := documentResponsible(doc); got != assignee {
+			t.Errorf("document was modified: responsible = %q", got)
+		}
+	})
+
+	t.Run("reopen and reassign", func(t *testing.T) {
+		doc := ticketDoc(TicketCompleted, assignee)
+		other := "john@example.com"
+		props, _, _, err := applyTicketLifecycle(doc, "me@example.com", now, UpdateTicketFieldsOptions{Reopen: true, Responsible: &other})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(props, []string{"status", "responsible"}) {
+			t.Errorf("changed properties = %v", props)
+		}
+	})
+
+	t.Run("legacy string responsible", func(t *testing.T) {
+		doc := ticketDoc(TicketStarted, "")
+		doc["participants"] = map[string]interface{}{"responsible": assignee}
+		if got := documentResponsible(doc); got != assignee {
+			t.Errorf("documentResponsible() = %q", got)
+		}
+	})
+}
+
+func TestNextAuditStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    string
+		transition AuditTransition
+		want       string
+		wantErr    bool
+	}{
+		{name: "start started", current: AuditStarted, transition: AuditStart, want: AuditInProgress},
+		{name: "start in progress", current: AuditInProgress, transition: AuditStart, wantErr: true},
+		{name: "start completed", current: AuditCompleted, transition: AuditStart, wantErr: true},
+		{name: "complete started", current: AuditStarted, transition: AuditComplete, want: AuditCompleted},
+		{name: "complete in progress", current: AuditInProgress, transition: AuditComplete, want: AuditCompleted},
+		{name: "complete twice", current: AuditCompleted, transition: AuditComplete, wantErr: true},
+		{name: "reopen", current: AuditCompleted, transition: AuditReopen, want: AuditInProgress},
+		{name: "reopen active", current: AuditStarted, transition: AuditReopen, wantErr: true},
+		{name: "missing status", current: "", transition: AuditComplete, want: AuditCompleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextAuditStatus(tt.current, tt.transition)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nextAuditStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("nextAuditStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyAuditTransition(t *testing.T) {
+	const now = "2026-03-16T10:00:00.000Z"
+	newDoc := func(status string, answer []interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"status": status,
+			"dates":  map[string]interface{}{"creationDate": "2026-03-01T08:00:00.000Z"},
+			"questions": []interface{}{
+				map[string]interface{}{
+					"categoryName": "Fire safety",
+					"questions": []interface{}{
+						map[string]interface{}{"question": "Remarks", "settings": map[string]interface{}{"answertype": "freetext"}},
+						map[string]interface{}{"question": "Extinguisher", "answer": answer, "settings": map[string]interface{}{"answertype": "yesnona", "required": true}},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("required question unanswered", func(t *testing.T) {
+		_, err := applyAuditTransition(newDoc(AuditStarted, nil), now, AuditComplete, false)
+		unanswered, ok := err.(*UnansweredError)
+		if !ok {
+			t.Fatalf("error = %v, want UnansweredError", err)
+		}
+		if !reflect.DeepEqual(unanswered.Questions, []string{"Fire safety / 2. Extinguisher"}) {
+			t.Errorf("questions = %v", unanswered.Questions)
+		}
+	})
+
+	t.Run("force", func(t *testing.T) {
+		doc := newDoc(AuditStarted, nil)
+		if _, err := applyAuditTransition(doc, now, AuditComplete, true); err != nil {
+			t.Fatal(err)
+		}
+		if doc["status"] != AuditCompleted {
+			t.Errorf("status = %v", doc["status"])
+		}
+	})
+
+	t.Run("complete and reopen", func(t *testing.T) {
+		doc := newDoc(AuditInProgress, []interface{}{"yes"})
+		change, err := applyAuditTransition(doc, now, AuditComplete, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &AuditStatusChange{OldStatus: AuditInProgress, Status: AuditCompleted, CompletionDate: now}
+		if !reflect.DeepEqual(change, want) {
+			t.Errorf("change = %+v, want %+v", change, want)
+		}
+		dates := doc["dates"].(map[string]interface{})
+		if dates["completionDate"] != now {
+			t.Errorf("completionDate = %v", dates["completionDate"])
+		}
+
+		if _, err := applyAuditTransition(doc, now, AuditReopen, false); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := dates["completionDate"]; ok || doc["status"] != AuditInProgress {
+			t.Errorf("after reopen: status = %v, dates = %v", doc["status"], dates)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"sort"
+)
+
+// Operation is a single entry in a document's operation log (change history)
+type Operation struct {
+	Author            string             `json:"author"`
+	Time              string             `json:"time"`
+	ChangedProperties []string           `json:"changedProperties"`
+	OldValues         []interface{}      `json:"oldValues"`
+	NewValues         []interface{}      `json:"newValues"`
+	Summary           string             `json:"summary,omitempty"`
+	ActionType        string             `json:"actionType,omitempty"`
+	Platform          *OperationPlatform `json:"platform,omitempty"`
+}
+
+// OperationPlatform identifies the client that made a change
+type OperationPlatform struct {
+	UserInterface    string `json:"userInterface,omitempty"`
+	InterfaceVersion string `json:"interfaceVersion,omitempty"`
+}
+
+// GetOperations returns the operation log of a document in chronological order
+func (c *Client) GetOperations(database, docID string) ([]Operation, error) {
+	doc, err := c.GetDocument(database, docID)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOperations(doc), nil
+}
+
+// ParseOperations extracts the operation log from a raw document, sorted by time.
+// Operations without a timestamp come first, in their original order.
+func ParseOperations(doc map[string]interface{}) []Operation {
+	raw, _ := doc["operation"].([]interface{})
+	ops := make([]Operation, 0, len(raw))
+	for _, r := range raw {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ops = append(ops, parseOperation(m))
+	}
+
+	// An empty time sorts before any timestamp
+	sort.SliceStable(ops, func(i, j int) bool {
+		return ops[i].Time < ops[j].Time
+	})
+
+	return ops
+}
+
+func parseOperation(m map[string]interface{}) Operation {
+	var op Operation
+
+	// The author is usually an email, but older documents store a Person object
+	switch a := m["author"].(type) {
+	case string:
+		op.Author = a
+	case map[string]interface{}:
+		op.Author, _ = a["email"].(string)
+	}
+
+	op.Time, _ = m["time"].(string)
+	op.Summary, _ = m["summary"].(string)
+	op.ActionType, _ = m["actionType"].(string)
+
+	if props, ok := m["changedProperties"].([]interface{}); ok {
+		for _, p := range props {
+			if s, ok := p.(string); ok {
+				op.ChangedProperties = append(op.ChangedProperties, s)
+			}
+		}
+	}
+	op.OldValues, _ = m["oldValues"].([]interface{})
+	op.NewValues, _ = m["newValues"].([]interface{})
+
+	if p, ok := m["platform"].(map[string]interface{}); ok {
+		op.Platform = &OperationPlatform{}
+		op.Platform.UserInterface, _ = p["userInterface"].(string)
+		op.Platform.InterfaceVersion, _ = p["interfaceVersion"].(string)
+	}
+
+	return op
+}
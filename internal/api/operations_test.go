@@ -0,0 +1,27 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOperationsOrder(t *testing.T) {
+	doc := map[string]interface{}{
+		"operation": []interface{}{
+			map[string]interface{}{"time": "2026-03-02T10:00:00.000Z", "summary": "second"},
+			map[string]interface{}{"summary": "untimed 1"},
+			map[string]interface{}{"time": "2026-03-01T10:00:00.000Z", "summary": "first"},
+			"not an operation",
+			map[string]interface{}{"summary": "untimed 2"},
+			map[string]interface{}{"time": "2026-03-03T10:00:00.000Z", "summary": "third"},
+		},
+	}
+
+	var got []string
+	for _, op := range ParseOperations(doc) {
+		got = append(got, op.Summary)
+	}
+	if want := []string{"untimed 1", "untimed 2", "first", "second", "third"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
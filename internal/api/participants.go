@@ -0,0 +1,166 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParticipantsEdit describes changes to the informed and consulted participants of a document
+type ParticipantsEdit struct {
+	Inform  []string // Emails to add to the informed list
+	Consult []string // Emails to add to the consulted list
+	Remove  []string // Emails to remove from both the informed and consulted lists
+}
+
+// IsEmpty returns true if the edit doesn't change anything
+func (e ParticipantsEdit) IsEmpty() bool {
+	return len(e.Inform) == 0 && len(e.Consult) == 0 && len(e.Remove) == 0
+}
+
+// GetParticipants returns the participants of a ticket or audit
+func (c *Client) GetParticipants(database, docID string) (*Participants, error) {
+	doc, err := c.GetDocument(database, docID)
+	if err != nil {
+		return nil, err
+	}
+	return documentParticipants(doc), nil
+}
+
+// UpdateParticipants applies the edit to the informed and consulted participants of a
+// ticket or audit and records the change in the operation log. Returns the resulting participants.
+func (c *Client) UpdateParticipants(database, docID string, edit ParticipantsEdit) (*Participants, error) {
+	doc, err := c.GetDocument(database, docID)
+	if err != nil {
+		return nil, fmt.Errorf("getting document: %w", err)
+	}
+
+	changedProps, oldValues, newValues := applyParticipantsEdit(doc, edit)
+	if len(changedProps) == 0 {
+		return documentParticipants(doc), nil
+	}
+
+	email, err := c.Email()
+	if err != nil {
+		return nil, fmt.Errorf("getting user email: %w", err)
+	}
+
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	markModified(doc, email, now)
+	appendOperation(doc, email, now, changedProps, oldValues, newValues)
+
+	if err := c.UpdateDocument(database, docID, doc); err != nil {
+		return nil, err
+	}
+	return documentParticipants(doc), nil
+}
+
+// applyParticipantsEdit changes the informed and consulted participants of a document
+// and returns the changed properties with their old and new values
+func applyParticipantsEdit(doc map[string]interface{}, edit ParticipantsEdit) ([]string, []interface{}, []interface{}) {
+	participants, ok := doc["participants"].(map[string]interface{})
+	if !ok {
+		participants = map[string]interface{}{"type": "IB.EdBundle.Document.Participants"}
+		doc["participants"] = participants
+	}
+
+	oldInformed := personEmails(participants["informed"])
+	oldConsulted := personEmails(participants["consulted"])
+	newInformed := editEmails(oldInformed, edit.Inform, edit.Remove)
+	newConsulted := editEmails(oldConsulted, edit.Consult, edit.Remove)
+
+	var changedProps []string
+	var oldValues, newValues []interface{}
+	if !equalStrings(oldInformed, newInformed) {
+		participants["informed"] = personList(newInformed)
+		changedProps = append(changedProps, "participants.informed")
+		oldValues = append(oldValues, oldInformed)
+		newValues = append(newValues, newInformed)
+	}
+	if !equalStrings(oldConsulted, newConsulted) {
+		participants["consulted"] = personList(newConsulted)
+		changedProps = append(changedProps, "participants.consulted")
+		oldValues = append(oldValues, oldConsulted)
+		newValues = append(newValues, newConsulted)
+	}
+
+	return changedProps, oldValues, newValues
+}
+
+// editEmails adds and removes emails from a list, comparing case-insensitively.
+// Existing entries keep their order; new entries are appended.
+func editEmails(current, add, remove []string) []string {
+	removed := make(map[string]bool, len(remove))
+	for _, e := range remove {
+		removed[strings.ToLower(e)] = true
+	}
+
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, list := range [][]string{current, add} {
+		for _, e := range list {
+			key := strings.ToLower(strings.TrimSpace(e))
+			if key == "" || seen[key] || removed[key] {
+				continue
+			}
+			seen[key] = true
+			result = append(result, strings.TrimSpace(e))
+		}
+	}
+	return result
+}
+
+// personEmails extracts emails from a list of Person objects (or plain email strings)
+func personEmails(v interface{}) []string {
+	emails := []string{}
+	list, _ := v.([]interface{})
+	for _, item := range list {
+		switch p := item.(type) {
+		case string:
+			emails = append(emails, p)
+		case map[string]interface{}:
+			if email, ok := p["email"].(string); ok && email != "" {
+				emails = append(emails, email)
+			}
+		}
+	}
+	return emails
+}
+
+func personList(emails []string) []interface{} {
+	list := make([]interface{}, 0, len(emails))
+	for _, e := range emails {
+		list = append(list, map[string]interface{}{
+			"type":  "IB.EdBundle.Document.Person",
+			"email": e,
+		})
+	}
+	return list
+}
+
+func documentParticipants(doc map[string]interface{}) *Participants {
+	result := &Participants{}
+	participants, ok := doc["participants"].(map[string]interface{})
+	if !ok {
+		return result
+	}
+
+	switch r := participants["responsible"].(type) {
+	case string:
+		if r != "" {
+			result.Responsible = &Person{Email: r}
+		}
+	case map[string]interface{}:
+		if email, ok := r["email"].(string); ok && email != "" {
+			result.Responsible = &Person{Email: email, Type: "IB.EdBundle.Document.Person"}
+		}
+	}
+
+	for _, e := range personEmails(participants["informed"]) {
+		result.Informed = append(result.Informed, Person{Email: e, Type: "IB.EdBundle.Document.Person"})
+	}
+	for _, e := range personEmails(participants["consulted"]) {
+		result.Consulted = append(result.Consulted, Person{Email: e, Type: "IB.EdBundle.Document.Person"})
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditEmails(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []string
+		add     []string
+		remove  []string
+		want    []string
+	}{
+		{name: "add to empty", add: []string{"qa@example.com"}, want: []string{"qa@example.com"}},
+		{name: "add keeps existing", current: []string{"a@example.com"}, add: []string{"b@example.com"}, want: []string{"a@example.com", "b@example.com"}},
+		{name: "add is case-insensitive", current: []string{"QA@example.com"}, add: []string{"qa@example.com"}, want: []string{"QA@example.com"}},
+		{name: "remove", current: []string{"a@example.com", "b@example.com"}, remove: []string{"A@example.com"}, want: []string{"b@example.com"}},
+		{name: "remove wins over add", add: []string{"a@example.com"}, remove: []string{"a@example.com"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := editEmails(tt.current, tt.add, tt.remove)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("editEmails() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPersonEmails(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{"type": "IB.EdBundle.Document.Person", "email": "a@example.com"},
+		"b@example.com",
+		map[string]interface{}{"type": "IB.EdBundle.Document.Person"},
+	}
+	want := []string{"a@example.com", "b@example.com"}
+	if got := personEmails(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("personEmails() = %v, want %v", got, want)
+	}
+}
@@ -3,7 +3,10 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -32,10 +35,14 @@ type TemplateQuestion struct {
 type TemplateQuestionSettings struct {
 	AnswerType     string                  `json:"answertype"`
 	TicketRequired bool                    `json:"ticketRequired"`
+	Required       bool                    `json:"required,omitempty"`
 	Choice         string                  `json:"choice,omitempty"`
 	Answer         []string                `json:"answer,omitempty"`
 	RichOptions    []RichOption            `json:"richOptions,omitempty"`
 	Styling        *QuestionStyling        `json:"styling,omitempty"`
+	Min            *float64                `json:"min,omitempty"`  // Lowest value of a numeric answer or rating scale
+	Max            *float64                `json:"max,omitempty"`  // Highest value of a numeric answer or rating scale
+	Unit           string                  `json:"unit,omitempty"` // Unit of a numeric answer, e.g. "mm"
 }
 
 // RichOption represents an option in a multiplechoice question.
@@ -72,64 +79,148 @@ var validAnswerTypes = map[string]bool{
 	"statictext":     true,
 }
 
+// Severities of template issues
+const (
+	SeverityError   = "error"   // The template is invalid
+	SeverityWarning = "warning" // The template works but is probably not what was meant
+)
+
+// TemplateIssue is a problem in template questions found by LintTemplateQuestions
+type TemplateIssue struct {
+	Severity string `json:"severity"`
+	Location string `json:"location,omitempty"` // e.g. "category 1, question 2"
+	Message  string `json:"message"`
+}
+
+func (i TemplateIssue) String() string {
+	if i.Location == "" {
+		return i.Message
+	}
+	return i.Location + ": " + i.Message
+}
+
+type templateIssues []TemplateIssue
+
+func (l *templateIssues) errorf(location, format string, args ...interface{}) {
+	*l = append(*l, TemplateIssue{Severity: SeverityError, Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *templateIssues) warnf(location, format string, args ...interface{}) {
+	*l = append(*l, TemplateIssue{Severity: SeverityWarning, Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+// ratingScaleMax is the highest upper bound of a rating scale
+const ratingScaleMax = 10
+
 // ValidateTemplateQuestions validates a slice of template categories.
-// All errors are collected and returned as a single joined error.
+// All errors are collected and returned as a single joined error; warnings of
+// LintTemplateQuestions are ignored.
 func ValidateTemplateQuestions(categories []TemplateCategory) error {
+	if len(categories) == 0 {
+		return fmt.Errorf("questions file must contain at least 1 category")
+	}
+
 	var errs []string
+	for _, issue := range LintTemplateQuestions(categories) {
+		if issue.Severity == SeverityError {
+			errs = append(errs, issue.String())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation errors:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+
+	return nil
+}
+
+// LintTemplateQuestions checks template categories and returns all errors and
+// warnings, in template order.
+func LintTemplateQuestions(categories []TemplateCategory) []TemplateIssue {
+	issues := templateIssues{}
 
 	if len(categories) == 0 {
-		return fmt.Errorf("questions file must contain at least 1 category")
+		issues.errorf("", "template must contain at least 1 category")
+		return issues
 	}
 
 	for ci, cat := range categories {
 		catNum := ci + 1
+		catPrefix := fmt.Sprintf("category %d", catNum)
 
 		if strings.TrimSpace(cat.CategoryName) == "" {
-			errs = append(errs, fmt.Sprintf("category %d: categoryName is required", catNum))
+			issues.errorf(catPrefix, "categoryName is required")
+		}
+		if len(cat.Questions) == 0 {
+			issues.warnf(catPrefix, "category has no questions")
 		}
 
+		seen := make(map[string]int)
 		for qi, q := range cat.Questions {
 			qNum := qi + 1
 			prefix := fmt.Sprintf("category %d, question %d", catNum, qNum)
 
 			if strings.TrimSpace(q.Question) == "" {
-				errs = append(errs, fmt.Sprintf("%s: question text is required", prefix))
+				issues.errorf(prefix, "question text is required")
+			} else if first, ok := seen[textKey(q.Question)]; ok {
+				issues.warnf(prefix, "same question text as question %d", first)
+			} else {
+				seen[textKey(q.Question)] = qNum
 			}
 
 			at := q.Settings.AnswerType
 			if at == "" {
-				errs = append(errs, fmt.Sprintf("%s: settings.answertype is required", prefix))
+				issues.errorf(prefix, "settings.answertype is required")
 				continue
 			}
 			if !validAnswerTypes[at] {
-				errs = append(errs, fmt.Sprintf("%s: invalid answertype %q (must be one of: yesnona, freetext, multiplechoice, numeric, rating, date, time, duration, signature, statictext)", prefix, at))
+				issues.errorf(prefix, "invalid answertype %q (must be one of: yesnona, freetext, multiplechoice, numeric, rating, date, time, duration, signature, statictext)", at)
 				continue
 			}
 
-			if at == "multiplechoice" {
-				validateMultipleChoice(prefix, q.Settings, &errs)
+			switch at {
+			case "multiplechoice":
+				validateMultipleChoice(prefix, q.Settings, &issues)
+			case "numeric":
+				validateNumeric(prefix, q.Settings, &issues)
+			case "rating":
+				validateRating(prefix, q.Settings, &issues)
+			case "statictext":
+				if len(q.Answer) > 0 || len(q.Settings.Answer) > 0 || len(q.Settings.RichOptions) > 0 {
+					issues.errorf(prefix, "statictext question can't have answers or options")
+				}
 			}
-		}
-	}
 
-	if len(errs) > 0 {
-		return fmt.Errorf("validation errors:\n  - %s", strings.Join(errs, "\n  - "))
+			if at != "numeric" && at != "rating" {
+				if q.Settings.Min != nil || q.Settings.Max != nil {
+					issues.warnf(prefix, "min and max are only used by numeric and rating questions")
+				}
+			}
+			if at != "numeric" && q.Settings.Unit != "" {
+				issues.warnf(prefix, "unit is only used by numeric questions")
+			}
+			if q.Settings.TicketRequired && at == "statictext" {
+				issues.errorf(prefix, "ticketRequired is not allowed on a statictext question, it can't be answered")
+			}
+
+			validateStyling(prefix, q.Settings, &issues)
+		}
 	}
 
-	return nil
+	return issues
 }
 
-func validateMultipleChoice(prefix string, s TemplateQuestionSettings, errs *[]string) {
+func validateMultipleChoice(prefix string, s TemplateQuestionSettings, issues *templateIssues) {
 	if s.Choice != "single" && s.Choice != "multiple" {
-		*errs = append(*errs, fmt.Sprintf("%s: multiplechoice question requires 'choice' field set to \"single\" or \"multiple\"", prefix))
+		issues.errorf(prefix, "multiplechoice question requires 'choice' field set to \"single\" or \"multiple\"")
 	}
 
 	if len(s.Answer) == 0 {
-		*errs = append(*errs, fmt.Sprintf("%s: multiplechoice question requires at least 1 option ID in settings.answer", prefix))
+		issues.errorf(prefix, "multiplechoice question requires at least 1 option ID in settings.answer")
 	}
 
 	if len(s.RichOptions) == 0 {
-		*errs = append(*errs, fmt.Sprintf("%s: multiplechoice question requires at least 1 richOption", prefix))
+		issues.errorf(prefix, "multiplechoice question requires at least 1 richOption")
 		return
 	}
 
@@ -137,13 +228,13 @@ func validateMultipleChoice(prefix string, s TemplateQuestionSettings, errs *[]s
 	for i, ro := range s.RichOptions {
 		roPrefix := fmt.Sprintf("%s, richOption %d", prefix, i+1)
 		if ro.ID == "" {
-			*errs = append(*errs, fmt.Sprintf("%s: id is required", roPrefix))
+			issues.errorf(roPrefix, "id is required")
 		}
 		if strings.TrimSpace(ro.Text) == "" {
-			*errs = append(*errs, fmt.Sprintf("%s: text is required", roPrefix))
+			issues.errorf(roPrefix, "text is required")
 		}
 		if ro.Type != "textselect" {
-			*errs = append(*errs, fmt.Sprintf("%s: type must be \"textselect\", got %q", roPrefix, ro.Type))
+			issues.errorf(roPrefix, "type must be \"textselect\", got %q", ro.Type)
 		}
 	}
 
@@ -162,33 +253,126 @@ func validateMultipleChoice(prefix string, s TemplateQuestionSettings, errs *[]s
 
 		for _, id := range s.Answer {
 			if !richIDs[id] {
-				*errs = append(*errs, fmt.Sprintf("%s: settings.answer references ID %q not found in richOptions", prefix, id))
+				issues.errorf(prefix, "settings.answer references ID %q not found in richOptions", id)
 			}
 		}
 		for _, ro := range s.RichOptions {
 			if ro.ID != "" && !answerIDs[ro.ID] {
-				*errs = append(*errs, fmt.Sprintf("%s: richOption ID %q not found in settings.answer", prefix, ro.ID))
+				issues.errorf(prefix, "richOption ID %q not found in settings.answer", ro.ID)
+			}
+		}
+	}
+}
+
+func validateNumeric(prefix string, s TemplateQuestionSettings, issues *templateIssues) {
+	if s.Min != nil && s.Max != nil && *s.Min > *s.Max {
+		issues.errorf(prefix, "min (%g) is greater than max (%g)", *s.Min, *s.Max)
+	}
+	if s.Unit != strings.TrimSpace(s.Unit) {
+		issues.warnf(prefix, "unit %q has leading or trailing spaces", s.Unit)
+	}
+}
+
+func validateRating(prefix string, s TemplateQuestionSettings, issues *templateIssues) {
+	for _, bound := range []struct {
+		name  string
+		value *float64
+	}{{"min", s.Min}, {"max", s.Max}} {
+		if bound.value == nil {
+			continue
+		}
+		v := *bound.value
+		if v != math.Trunc(v) || v < 0 || v > ratingScaleMax {
+			issues.errorf(prefix, "rating %s must be a whole number from 0 to %d, got %g", bound.name, ratingScaleMax, v)
+		}
+	}
+	if lo, hi := ratingBounds(s); lo >= hi {
+		issues.errorf(prefix, "rating scale %d-%d is empty (min must be below max)", lo, hi)
+	}
+}
+
+// ratingBounds returns the scale of a rating question, 1 to 5 unless the question
+// sets min or max
+func ratingBounds(s TemplateQuestionSettings) (int, int) {
+	lo, hi := 1, ratingMax
+	if s.Min != nil {
+		lo = int(*s.Min)
+	}
+	if s.Max != nil {
+		hi = int(*s.Max)
+	}
+	return lo, hi
+}
+
+// validateStyling checks that styling keys refer to answers of the question: rich
+// option IDs for multiplechoice, YES, NO and N/A for yesnona
+func validateStyling(prefix string, s TemplateQuestionSettings, issues *templateIssues) {
+	if s.Styling == nil || len(s.Styling.Options) == 0 {
+		return
+	}
+
+	valid := make(map[string]bool)
+	switch s.AnswerType {
+	case "multiplechoice":
+		for _, ro := range s.RichOptions {
+			valid[ro.ID] = true
+		}
+	case "yesnona":
+		for _, key := range yesNoStylingKeys {
+			valid[key] = true
+		}
+	default:
+		issues.warnf(prefix, "styling is only used by multiplechoice and yesnona questions")
+		return
+	}
+
+	keys := make([]string, 0, len(s.Styling.Options))
+	for key := range s.Styling.Options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !valid[key] {
+			if s.AnswerType == "yesnona" {
+				issues.errorf(prefix, "styling key %q must be YES, NO or N/A", key)
+			} else {
+				issues.errorf(prefix, "styling key %q not found in richOptions", key)
 			}
 		}
 	}
 }
 
 // LoadAndValidateQuestionsFile reads a JSON file, parses it as template categories,
-// validates the structure, and returns the typed categories.
+// validates the structure, and returns the typed categories. YAML files (.yaml, .yml)
+// are compiled with BuildTemplateQuestions.
 func LoadAndValidateQuestionsFile(path string) ([]TemplateCategory, error) {
+	categories, err := LoadQuestionsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateTemplateQuestions(categories); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// LoadQuestionsFile reads a JSON or YAML questions file without validating it
+func LoadQuestionsFile(path string) ([]TemplateCategory, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("reading questions file: %w", err)
 	}
 
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return compileTemplateQuestions(data)
+	}
+
 	var categories []TemplateCategory
 	if err := json.Unmarshal(data, &categories); err != nil {
 		return nil, fmt.Errorf("parsing questions file: %w", err)
 	}
-
-	if err := ValidateTemplateQuestions(categories); err != nil {
-		return nil, err
-	}
-
 	return categories, nil
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -448,3 +449,72 @@ func TestLoadAndValidateQuestionsFile(t *testing.T) {
 		}
 	})
 }
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestLintTemplateQuestions(t *testing.T) {
+	q := func(text, answerType string) TemplateQuestion {
+		return TemplateQuestion{Question: text, Settings: TemplateQuestionSettings{AnswerType: answerType}}
+	}
+
+	tests := []struct {
+		name     string
+		question TemplateQuestion
+		severity string // empty means no issue expected
+		want     string
+	}{
+		{name: "numeric range", question: TemplateQuestion{Question: "Q", Settings: TemplateQuestionSettings{AnswerType: "numeric", Min: floatPtr(0), Max: floatPtr(100), Unit: "mm"}}},
+		{name: "numeric min above max", question: TemplateQuestion{Question: "Q", Settings: TemplateQuestionSettings{AnswerType: "numeric", Min: floatPtr(5), Max: floatPtr(1)}}, severity: SeverityError, want: "min (5) is greater than max (1)"},
+		{name: "unit on text", question: TemplateQuestion{Question: "Q", Settings: TemplateQuestionSettings{AnswerType: "freetext", Unit: "mm"}}, severity: SeverityWarning, want: "unit is only used by numeric questions"},
+		{name: "rating scale", question: TemplateQuestion{Question: "Q", Settings: TemplateQuestionSettings{AnswerType: "rating", Min: floatPtr(0), Max: floatPtr(10)}}},
+		{name: "rating fraction", question: TemplateQuestion{Question: "Q", Settings: TemplateQuestionSettings{AnswerType: "rating", Max: floatPtr(4.5)}}, severity: SeverityError, want: "rating max must be a whole number"},
+		{name: "rating too large", question: TemplateQuestion{Question: "Q", Settings: TemplateQuestionSettings{AnswerType: "rating", Max: floatPtr(20)}}, severity: SeverityError, want: "from 0 to 10"},
+		{name: "rating empty scale", question: TemplateQuestion{Question: "Q", Settings: TemplateQuestionSettings{AnswerType: "rating", Min: floatPtr(6)}}, severity: SeverityError, want: "rating scale 6-5 is empty"},
+		{name: "yesnona styling key", question: TemplateQuestion{Question: "Q", Settings: TemplateQuestionSettings{AnswerType: "yesnona", Styling: &QuestionStyling{Options: map[string]StylingOption{"MAYBE": {}}}}}, severity: SeverityError, want: `styling key "MAYBE" must be YES, NO or N/A`},
+		{name: "multiplechoice styling key", question: TemplateQuestion{Question: "Q", Settings: TemplateQuestionSettings{
+			AnswerType:  "multiplechoice",
+			Choice:      "single",
+			Answer:      []string{"O1"},
+			RichOptions: []RichOption{{ID: "O1", Text: "A", Type: "textselect"}},
+			Styling:     &QuestionStyling{Options: map[string]StylingOption{"O1": {}, "O9": {}}},
+		}}, severity: SeverityError, want: `styling key "O9" not found in richOptions`},
+		{name: "styling on text", question: TemplateQuestion{Question: "Q", Settings: TemplateQuestionSettings{AnswerType: "freetext", Styling: &QuestionStyling{Options: map[string]StylingOption{"YES": {}}}}}, severity: SeverityWarning, want: "styling is only used by"},
+		{name: "statictext with answer", question: TemplateQuestion{Question: "Q", Answer: []interface{}{"x"}, Settings: TemplateQuestionSettings{AnswerType: "statictext"}}, severity: SeverityError, want: "statictext question can't have answers"},
+		{name: "statictext with ticket", question: TemplateQuestion{Question: "Q", Settings: TemplateQuestionSettings{AnswerType: "statictext", TicketRequired: true}}, severity: SeverityError, want: "ticketRequired is not allowed"},
+		{name: "plain yesnona", question: q("Q", "yesnona")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := LintTemplateQuestions([]TemplateCategory{{CategoryName: "Cat", Questions: []TemplateQuestion{tt.question}}})
+			if tt.severity == "" {
+				if len(issues) != 0 {
+					t.Errorf("expected no issues, got %v", issues)
+				}
+				return
+			}
+			if len(issues) != 1 || issues[0].Severity != tt.severity || !strings.Contains(issues[0].Message, tt.want) {
+				t.Errorf("expected one %s containing %q, got %v", tt.severity, tt.want, issues)
+			}
+		})
+	}
+
+	t.Run("category warnings", func(t *testing.T) {
+		issues := LintTemplateQuestions([]TemplateCategory{
+			{CategoryName: "Empty"},
+			{CategoryName: "Dupes", Questions: []TemplateQuestion{q("<p>Door closed?</p>", "yesnona"), q("door  closed?", "yesnona")}},
+		})
+		want := []TemplateIssue{
+			{Severity: SeverityWarning, Location: "category 1", Message: "category has no questions"},
+			{Severity: SeverityWarning, Location: "category 2, question 2", Message: "same question text as question 1"},
+		}
+		if !reflect.DeepEqual(issues, want) {
+			t.Errorf("LintTemplateQuestions() = %v, want %v", issues, want)
+		}
+		if err := ValidateTemplateQuestions([]TemplateCategory{{CategoryName: "Empty"}}); err != nil {
+			t.Errorf("warnings should not fail validation, got %v", err)
+		}
+	})
+}
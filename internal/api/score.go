@@ -0,0 +1,422 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CategoryScore summarizes the answers in one category, or in a whole audit
+type CategoryScore struct {
+	Category   string `json:"category"`
+	Questions  int    `json:"questions"` // Questions that can be answered (excludes static text)
+	Answered   int    `json:"answered"`
+	Unanswered int    `json:"unanswered"`
+	Yes        int    `json:"yes"`
+	No         int    `json:"no"`
+	NA         int    `json:"na"`
+}
+
+// Compliance returns the percentage of yes answers among the yes and no answers.
+// Returns false when the category has no yes or no answers.
+func (s CategoryScore) Compliance() (float64, bool) {
+	if s.Yes+s.No == 0 {
+		return 0, false
+	}
+	return float64(s.Yes) * 100 / float64(s.Yes+s.No), true
+}
+
+// add adds the counts of other to s
+func (s *CategoryScore) add(other CategoryScore) {
+	s.Questions += other.Questions
+	s.Answered += other.Answered
+	s.Unanswered += other.Unanswered
+	s.Yes += other.Yes
+	s.No += other.No
+	s.NA += other.NA
+}
+
+// AuditScore is the score of an audit per category and in total
+type AuditScore struct {
+	Categories []CategoryScore `json:"categories"`
+	Total      CategoryScore   `json:"total"`
+}
+
+// ScoreAudit counts the answers of an audit. Yes/no/n.a. answers are counted for
+// yesnona questions; static text questions are ignored.
+func ScoreAudit(audit *Audit) AuditScore {
+	var score AuditScore
+	for _, category := range audit.Questions {
+		cs := CategoryScore{Category: category.CategoryName}
+		for _, q := range category.Questions {
+			answerType := ""
+			if q.Settings != nil {
+				answerType = q.Settings.AnswerType
+			}
+			if answerType == "statictext" {
+				continue
+			}
+
+			cs.Questions++
+			if !IsAnswered(q.Answer) {
+				cs.Unanswered++
+				continue
+			}
+			cs.Answered++
+
+			if answerType == "yesnona" {
+				switch yesNoValue(q.Answer) {
+				case "yes":
+					cs.Yes++
+				case "no":
+					cs.No++
+				case "na":
+					cs.NA++
+				}
+			}
+		}
+		score.Categories = append(score.Categories, cs)
+		score.Total.add(cs)
+	}
+	score.Total.Category = "Total"
+	return score
+}
+
+// IsAnswered reports whether an answer contains a value
+func IsAnswered(answer []interface{}) bool {
+	for _, a := range answer {
+		switch v := a.(type) {
+		case nil:
+		case string:
+			if strings.TrimSpace(v) != "" {
+				return true
+			}
+		case map[string]interface{}:
+			if len(v) > 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// yesNoValue returns "yes", "no" or "na" for a yesnona answer, or "" if unknown
+func yesNoValue(answer []interface{}) string {
+	for _, a := range answer {
+		switch v := a.(type) {
+		case bool:
+			if v {
+				return "yes"
+			}
+			return "no"
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "yes", "true":
+				return "yes"
+			case "no", "false":
+				return "no"
+			case "na", "n/a", "n.a.":
+				return "na"
+			}
+		}
+	}
+	return ""
+}
+
+// AnswerText returns a readable version of an answer: option texts for multiple
+// choice questions, styling labels or Yes/No/N.A. for yesnona questions.
+func AnswerText(settings *QuestionSettings, answer []interface{}) string {
+	if settings == nil {
+		settings = &QuestionSettings{}
+	}
+
+	if settings.AnswerType == "yesnona" {
+		value := yesNoValue(answer)
+		if style := AnswerStyle(settings, answer); style != nil && style.Label != "" {
+			return style.Label
+		}
+		switch value {
+		case "yes":
+			return "Yes"
+		case "no":
+			return "No"
+		case "na":
+			return "N.A."
+		}
+	}
+
+	var parts []string
+	for _, a := range answer {
+		switch v := a.(type) {
+		case string:
+			if v == "" {
+				continue
+			}
+			if settings.AnswerType == "multiplechoice" {
+				v = optionText(settings, v)
+			}
+			parts = append(parts, v)
+		case float64:
+			parts = append(parts, strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			if v {
+				parts = append(parts, "Yes")
+			} else {
+				parts = append(parts, "No")
+			}
+		case map[string]interface{}:
+			for _, key := range []string{"text", "value", "date"} {
+				if s, ok := v[key].(string); ok && s != "" {
+					parts = append(parts, s)
+					break
+				}
+			}
+		case nil:
+		default:
+			parts = append(parts, fmt.Sprint(v))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// optionText returns the text of a multiple choice option, or id if it is unknown
+func optionText(settings *QuestionSettings, id string) string {
+	for _, opt := range settings.RichOptions {
+		if opt.ID == id && opt.Text != "" {
+			return opt.Text
+		}
+	}
+	return id
+}
+
+// AnswerStyle returns the styling of the (first) answer value, if the question has any
+func AnswerStyle(settings *QuestionSettings, answer []interface{}) *StylingOption {
+	if settings == nil || settings.Styling == nil || len(settings.Styling.Options) == 0 {
+		return nil
+	}
+
+	var key string
+	if settings.AnswerType == "yesnona" {
+		key = yesNoValue(answer)
+		if key == "na" {
+			// styling keys are YES, NO and N/A
+			key = "N/A"
+		}
+	} else if len(answer) > 0 {
+		key, _ = answer[0].(string)
+	}
+	if key == "" {
+		return nil
+	}
+
+	if style, ok := settings.Styling.Options[key]; ok {
+		return &style
+	}
+	for k, style := range settings.Styling.Options {
+		if strings.EqualFold(k, key) {
+			return &style
+		}
+	}
+	return nil
+}
+
+// MarshalJSON adds the compliance percentage (null without yes/no answers)
+func (s CategoryScore) MarshalJSON() ([]byte, error) {
+	type plain CategoryScore
+	out := struct {
+		plain
+		Compliance *float64 `json:"compliance"`
+	}{plain: plain(s)}
+	if pct, ok := s.Compliance(); ok {
+		out.Compliance = &pct
+	}
+	return json.Marshal(out)
+}
+
+// QuestionAverage summarizes the answers to a numeric or rating question
+type QuestionAverage struct {
+	Category   string  `json:"category"`
+	Question   string  `json:"question"`
+	AnswerType string  `json:"answerType"`
+	Count      int     `json:"count"`
+	Average    float64 `json:"average"`
+	Min        float64 `json:"min"`
+	Max        float64 `json:"max"`
+	sum        float64
+}
+
+func (a *QuestionAverage) add(value float64) {
+	if a.Count == 0 || value < a.Min {
+		a.Min = value
+	}
+	if a.Count == 0 || value > a.Max {
+		a.Max = value
+	}
+	a.Count++
+	a.sum += value
+	a.Average = a.sum / float64(a.Count)
+}
+
+// TrendPoint is the score of the audits of one template in one period
+type TrendPoint struct {
+	Project  string        `json:"project,omitempty"`
+	Template string        `json:"template"`
+	Period   string        `json:"period"`
+	Audits   int           `json:"audits"`
+	Score    CategoryScore `json:"score"`
+}
+
+// AuditSummary is the total score of a single audit in a set of statistics
+type AuditSummary struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Project  string        `json:"project,omitempty"`
+	Template string        `json:"template"`
+	Date     string        `json:"date,omitempty"`
+	Score    CategoryScore `json:"score"`
+}
+
+// AuditStats aggregates the scores of a set of audits: per category (matched by
+// name), averages of numeric and rating questions, and trends per template over time.
+type AuditStats struct {
+	Period     string            `json:"period"` // "week" or "month"
+	Audits     []AuditSummary    `json:"audits"`
+	Categories []CategoryScore   `json:"categories"`
+	Total      CategoryScore     `json:"total"`
+	Averages   []QuestionAverage `json:"averages"`
+	Trends     []TrendPoint      `json:"trends"`
+}
+
+// NewAuditStats returns empty statistics with trends grouped by period ("week" or "month")
+func NewAuditStats(period string) *AuditStats {
+	return &AuditStats{
+		Period:     period,
+		Audits:     []AuditSummary{},
+		Categories: []CategoryScore{},
+		Averages:   []QuestionAverage{},
+		Trends:     []TrendPoint{},
+		Total:      CategoryScore{Category: "Total"},
+	}
+}
+
+// Add adds an audit to the statistics. The date (completion or creation date)
+// determines the trend period; a zero date is grouped under "unknown".
+func (s *AuditStats) Add(audit *Audit, project, template string, date time.Time) {
+	score := ScoreAudit(audit)
+
+	summary := AuditSummary{
+		ID:       audit.CouchDbID,
+		Name:     audit.Name,
+		Project:  project,
+		Template: template,
+		Score:    score.Total,
+	}
+	if !date.IsZero() {
+		summary.Date = date.Format("2006-01-02")
+	}
+	s.Audits = append(s.Audits, summary)
+
+	for _, cs := range score.Categories {
+		s.category(cs.Category).add(cs)
+	}
+	s.Total.add(score.Total)
+
+	for _, category := range audit.Questions {
+		for _, q := range category.Questions {
+			if q.Settings == nil || (q.Settings.AnswerType != "numeric" && q.Settings.AnswerType != "rating") {
+				continue
+			}
+			if value, ok := numericAnswer(q.Answer); ok {
+				s.average(category.CategoryName, q.Question, q.Settings.AnswerType).add(value)
+			}
+		}
+	}
+
+	period := "unknown"
+	if !date.IsZero() {
+		period = PeriodKey(date, s.Period)
+	}
+	trend := s.trend(project, template, period)
+	trend.Audits++
+	trend.Score.add(score.Total)
+}
+
+func (s *AuditStats) category(name string) *CategoryScore {
+	for i := range s.Categories {
+		if s.Categories[i].Category == name {
+			return &s.Categories[i]
+		}
+	}
+	s.Categories = append(s.Categories, CategoryScore{Category: name})
+	return &s.Categories[len(s.Categories)-1]
+}
+
+func (s *AuditStats) average(category, question, answerType string) *QuestionAverage {
+	for i := range s.Averages {
+		if s.Averages[i].Category == category && s.Averages[i].Question == question {
+			return &s.Averages[i]
+		}
+	}
+	s.Averages = append(s.Averages, QuestionAverage{Category: category, Question: question, AnswerType: answerType})
+	return &s.Averages[len(s.Averages)-1]
+}
+
+// trend returns the trend point of a project, template and period, keeping the
+// trends sorted so that each template's periods are listed chronologically
+func (s *AuditStats) trend(project, template, period string) *TrendPoint {
+	find := func() int {
+		for i, t := range s.Trends {
+			if t.Project == project && t.Template == template && t.Period == period {
+				return i
+			}
+		}
+		return -1
+	}
+
+	if i := find(); i >= 0 {
+		return &s.Trends[i]
+	}
+
+	s.Trends = append(s.Trends, TrendPoint{Project: project, Template: template, Period: period, Score: CategoryScore{Category: template}})
+	sort.SliceStable(s.Trends, func(i, j int) bool {
+		a, b := s.Trends[i], s.Trends[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if a.Template != b.Template {
+			return a.Template < b.Template
+		}
+		return a.Period < b.Period
+	})
+	return &s.Trends[find()]
+}
+
+// PeriodKey returns the ISO week ("2026-W11") or month ("2026-03") of a date
+func PeriodKey(t time.Time, period string) string {
+	if period == "month" {
+		return t.Format("2006-01")
+	}
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// numericAnswer returns the value of a numeric or rating answer
+func numericAnswer(answer []interface{}) (float64, bool) {
+	for _, a := range answer {
+		switch v := a.(type) {
+		case float64:
+			return v, true
+		case string:
+			f, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(v), ",", ".", 1), 64)
+			if err == nil {
+				return f, true
+			}
+		}
+	}
+	return 0, false
+}
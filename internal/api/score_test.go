@@ -0,0 +1,152 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScoreAudit(t *testing.T) {
+	yesno := &QuestionSettings{AnswerType: "yesnona"}
+	audit := &Audit{Questions: []QuestionCategory{
+		{CategoryName: "Fire safety", Questions: []Question{
+			{Question: "Extinguisher", Answer: []interface{}{"yes"}, Settings: yesno},
+			{Question: "Exit signs", Answer: []interface{}{"no"}, Settings: yesno},
+			{Question: "Sprinklers", Answer: []interface{}{true}, Settings: yesno},
+			{Question: "Alarm", Answer: []interface{}{"na"}, Settings: yesno},
+			{Question: "Remarks", Settings: &QuestionSettings{AnswerType: "freetext"}},
+			{Question: "Read this", Settings: &QuestionSettings{AnswerType: "statictext"}},
+		}},
+		{CategoryName: "Notes", Questions: []Question{
+			{Question: "Exits", Answer: []interface{}{3.0}, Settings: &QuestionSettings{AnswerType: "numeric"}},
+		}},
+	}}
+
+	score := ScoreAudit(audit)
+	fire := score.Categories[0]
+	if fire.Questions != 5 || fire.Answered != 4 || fire.Unanswered != 1 || fire.Yes != 2 || fire.No != 1 || fire.NA != 1 {
+		t.Errorf("fire safety score = %+v", fire)
+	}
+	if pct, ok := fire.Compliance(); !ok || pct < 66.6 || pct > 66.7 {
+		t.Errorf("Compliance() = %v, %v", pct, ok)
+	}
+	if _, ok := score.Categories[1].Compliance(); ok {
+		t.Error("category without yes/no answers has a compliance percentage")
+	}
+	if score.Total.Questions != 6 || score.Total.Answered != 5 {
+		t.Errorf("total = %+v", score.Total)
+	}
+}
+
+func TestAnswerText(t *testing.T) {
+	choice := &QuestionSettings{
+		AnswerType:  "multiplechoice",
+		RichOptions: []RichOption{{ID: "opt1", Text: "Good"}, {ID: "opt2", Text: "Bad"}},
+	}
+	styled := &QuestionSettings{
+		AnswerType: "yesnona",
+		Styling: &QuestionStyling{Options: map[string]StylingOption{
+			"no":  {BackgroundColor: "#f44336", Label: "Not OK"},
+			"N/A": {BackgroundColor: "#9e9e9e", Label: "Skipped"},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		settings *QuestionSettings
+		answer   []interface{}
+		want     string
+	}{
+		{name: "yes", settings: &QuestionSettings{AnswerType: "yesnona"}, answer: []interface{}{"yes"}, want: "Yes"},
+		{name: "styling label", settings: styled, answer: []interface{}{"no"}, want: "Not OK"},
+		{name: "options", settings: choice, answer: []interface{}{"opt2", "opt1"}, want: "Bad, Good"},
+		{name: "number", settings: &QuestionSettings{AnswerType: "numeric"}, answer: []interface{}{2.5}, want: "2.5"},
+		{name: "no settings", answer: []interface{}{"text"}, want: "text"},
+		{name: "empty", settings: choice, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AnswerText(tt.settings, tt.answer); got != tt.want {
+				t.Errorf("AnswerText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if style := AnswerStyle(styled, []interface{}{"no"}); style == nil || style.BackgroundColor != "#f44336" {
+		t.Errorf("AnswerStyle() = %+v", style)
+	}
+	if style := AnswerStyle(styled, []interface{}{"na"}); style == nil || style.BackgroundColor != "#9e9e9e" {
+		t.Errorf("AnswerStyle(na) = %+v", style)
+	}
+}
+
+func TestAuditStats(t *testing.T) {
+	yesno := &QuestionSettings{AnswerType: "yesnona"}
+	numeric := &QuestionSettings{AnswerType: "numeric"}
+	audit := func(id string, answer string, exits interface{}) *Audit {
+		return &Audit{CouchDbID: id, Questions: []QuestionCategory{
+			{CategoryName: "Fire safety", Questions: []Question{
+				{Question: "Extinguisher", Answer: []interface{}{answer}, Settings: yesno},
+				{Question: "Exits", Answer: []interface{}{exits}, Settings: numeric},
+			}},
+		}}
+	}
+
+	stats := NewAuditStats("week")
+	stats.Add(audit("a1", "yes", 2.0), "site_b", "Weekly", time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+	stats.Add(audit("a2", "no", "4"), "site_b", "Weekly", time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC))
+	stats.Add(audit("a3", "yes", nil), "site_b", "Weekly", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+	stats.Add(audit("a4", "yes", 3.0), "site_a", "Weekly", time.Time{})
+
+	if len(stats.Audits) != 4 || len(stats.Categories) != 1 {
+		t.Fatalf("audits = %d, categories = %d", len(stats.Audits), len(stats.Categories))
+	}
+	if c := stats.Categories[0]; c.Yes != 3 || c.No != 1 || c.Unanswered != 1 {
+		t.Errorf("category = %+v", c)
+	}
+
+	if len(stats.Averages) != 1 {
+		t.Fatalf("averages = %+v", stats.Averages)
+	}
+	if a := stats.Averages[0]; a.Count != 3 || a.Average != 3 || a.Min != 2 || a.Max != 4 {
+		t.Errorf("average = %+v", a)
+	}
+
+	var periods []string
+	for _, p := range stats.Trends {
+		periods = append(periods, p.Project+" "+p.Period)
+	}
+	want := []string{"site_a unknown", "site_b 2026-W10", "site_b 2026-W11"}
+	if strings.Join(periods, ",") != strings.Join(want, ",") {
+		t.Errorf("trends = %v, want %v", periods, want)
+	}
+	if pct, _ := stats.Trends[2].Score.Compliance(); pct != 50 {
+		t.Errorf("week 11 compliance = %v, want 50", pct)
+	}
+}
+
+func TestPeriodKey(t *testing.T) {
+	date := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC) // ISO week 53 of 2026
+	if got := PeriodKey(date, "week"); got != "2026-W53" {
+		t.Errorf("week = %s", got)
+	}
+	if got := PeriodKey(date, "month"); got != "2027-01" {
+		t.Errorf("month = %s", got)
+	}
+}
+
+func TestCategoryScoreJSON(t *testing.T) {
+	data, err := json.Marshal(CategoryScore{Category: "A", Yes: 3, No: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"compliance":75`) || !strings.Contains(string(data), `"yes":3`) {
+		t.Errorf("JSON = %s", data)
+	}
+	data, _ = json.Marshal(CategoryScore{Category: "B"})
+	if !strings.Contains(string(data), `"compliance":null`) {
+		t.Errorf("JSON = %s", data)
+	}
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// TagEdit describes a change to a document's tag list.
+// Clear and Set are applied first, then Add and Remove.
+type TagEdit struct {
+	Clear  bool     // Start from an empty tag list
+	Set    []string // Replace the existing tags
+	Add    []string // Tags to add (duplicates are ignored)
+	Remove []string // Tags to remove
+}
+
+// IsEmpty returns true if the edit doesn't change anything
+func (e TagEdit) IsEmpty() bool {
+	return !e.Clear && e.Set == nil && len(e.Add) == 0 && len(e.Remove) == 0
+}
+
+// Apply returns the tag list that results from applying the edit to current.
+// The order of existing tags is kept; added tags are appended.
+func (e TagEdit) Apply(current []string) []string {
+	base := current
+	if e.Clear {
+		base = nil
+	} else if e.Set != nil {
+		base = e.Set
+	}
+
+	remove := make(map[string]bool, len(e.Remove))
+	for _, t := range e.Remove {
+		remove[t] = true
+	}
+
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, list := range [][]string{base, e.Add} {
+		for _, t := range list {
+			if t == "" || seen[t] || remove[t] {
+				continue
+			}
+			seen[t] = true
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// GetDocumentTags returns the tags of any document (ticket, audit, template, file or map)
+func (c *Client) GetDocumentTags(database, docID string) ([]string, error) {
+	doc, err := c.GetDocument(database, docID)
+	if err != nil {
+		return nil, err
+	}
+	return documentTags(doc), nil
+}
+
+// EditDocumentTags reads a document, applies the tag edit and writes it back with an
+// operation record. Returns the resulting tags. Nothing is written if the tags don't change.
+func (c *Client) EditDocumentTags(database, docID string, edit TagEdit) ([]string, error) {
+	doc, err := c.GetDocument(database, docID)
+	if err != nil {
+		return nil, fmt.Errorf("getting document: %w", err)
+	}
+
+	oldTags := documentTags(doc)
+	newTags := edit.Apply(oldTags)
+	if equalStrings(oldTags, newTags) {
+		return newTags, nil
+	}
+
+	email, err := c.Email()
+	if err != nil {
+		return nil, fmt.Errorf("getting user email: %w", err)
+	}
+
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+
+	doc["tags"] = newTags
+	markModified(doc, email, now)
+	appendOperation(doc, email, now, []string{"tags"}, []interface{}{oldTags}, []interface{}{newTags})
+
+	if err := c.UpdateDocument(database, docID, doc); err != nil {
+		return nil, err
+	}
+	return newTags, nil
+}
+
+func documentTags(doc map[string]interface{}) []string {
+	tags := []string{}
+	if existing, ok := doc["tags"].([]interface{}); ok {
+		for _, t := range existing {
+			if s, ok := t.(string); ok {
+				tags = append(tags, s)
+			}
+		}
+	}
+	return tags
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
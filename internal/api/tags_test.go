@@ -0,0 +1,98 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagEditApply(t *testing.T) {
+	current := []string{"snag", "electrical"}
+
+	tests := []struct {
+		name string
+		edit TagEdit
+		want []string
+	}{
+		{name: "empty edit keeps tags", edit: TagEdit{}, want: []string{"snag", "electrical"}},
+		{name: "add keeps existing tags", edit: TagEdit{Add: []string{"verified"}}, want: []string{"snag", "electrical", "verified"}},
+		{name: "add ignores duplicates", edit: TagEdit{Add: []string{"snag", "new", "new"}}, want: []string{"snag", "electrical", "new"}},
+		{name: "remove", edit: TagEdit{Remove: []string{"snag", "missing"}}, want: []string{"electrical"}},
+		{name: "add and remove", edit: TagEdit{Add: []string{"verified"}, Remove: []string{"snag"}}, want: []string{"electrical", "verified"}},
+		{name: "set replaces", edit: TagEdit{Set: []string{"a", "b"}}, want: []string{"a", "b"}},
+		{name: "set then add", edit: TagEdit{Set: []string{"a"}, Add: []string{"b"}}, want: []string{"a", "b"}},
+		{name: "clear", edit: TagEdit{Clear: true}, want: []string{}},
+		{name: "clear then add", edit: TagEdit{Clear: true, Add: []string{"fresh"}}, want: []string{"fresh"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.edit.Apply(current)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkModified(t *testing.T) {
+	const email = "jan@example.com"
+	person := map[string]interface{}{"type": "IB.EdBundle.Document.Person", "email": email}
+
+	tests := []struct {
+		name string
+		doc  map[string]interface{}
+		key  string
+		want interface{}
+	}{
+		{
+			name: "ticket",
+			doc:  map[string]interface{}{"type": "IB.EdBundle.Document.Ticket", "content": map[string]interface{}{}},
+			key:  "lastmodifier",
+			want: person,
+		},
+		{
+			name: "file",
+			doc:  map[string]interface{}{"type": "IB.EdBundle.Document.File", "content": map[string]interface{}{}},
+			key:  "lastModifier",
+			want: email,
+		},
+		{
+			name: "map",
+			doc:  map[string]interface{}{"content": map[string]interface{}{"lastModifier": "old@example.com"}},
+			key:  "lastModifier",
+			want: email,
+		},
+		{
+			name: "group",
+			doc:  map[string]interface{}{"content": map[string]interface{}{"lastmodifier": "old@example.com"}},
+			key:  "lastmodifier",
+			want: email,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markModified(tt.doc, email, "2024-05-01T12:00:00.000Z")
+			content := tt.doc["content"].(map[string]interface{})
+			if len(content) != 1 || !reflect.DeepEqual(content[tt.key], tt.want) {
+				t.Errorf("content = %v, want %s = %v", content, tt.key, tt.want)
+			}
+		})
+	}
+
+	template := map[string]interface{}{
+		"type":    "IB.EdBundle.Document.AuditTemplate",
+		"content": map[string]interface{}{},
+		"dates":   map[string]interface{}{},
+	}
+	markModified(template, email, "2024-05-01T12:00:00.000Z")
+	if got := template["lastmodifier"]; !reflect.DeepEqual(got, map[string]string{"email": email}) {
+		t.Errorf("template lastmodifier = %v", got)
+	}
+	if len(template["content"].(map[string]interface{})) != 0 {
+		t.Errorf("template content = %v, want unchanged", template["content"])
+	}
+	if got := template["dates"].(map[string]interface{})["lastModifiedDate"]; got != "2024-05-01T12:00:00.000Z" {
+		t.Errorf("lastModifiedDate = %v", got)
+	}
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BuildCategory is a category in the YAML template authoring format
+type BuildCategory struct {
+	Category  string          `yaml:"category"`
+	Duplicate bool            `yaml:"duplicate"`
+	Questions []BuildQuestion `yaml:"questions"`
+}
+
+// BuildQuestion is a question in the YAML template authoring format. Type defaults
+// to yesno; Options are answer options of choice questions, or the labels of yes,
+// no and n/a (in that order) for yesno questions.
+type BuildQuestion struct {
+	Question    string        `yaml:"q"`
+	Type        string        `yaml:"type"`
+	Description string        `yaml:"description"`
+	Required    bool          `yaml:"required"`
+	Ticket      bool          `yaml:"ticket"` // A ticket is required for a failed answer
+	Multiple    bool          `yaml:"multiple"`
+	Options     []BuildOption `yaml:"options"`
+	Min         *float64      `yaml:"min"`  // Lowest value of a number or rating question
+	Max         *float64      `yaml:"max"`  // Highest value of a number or rating question
+	Unit        string        `yaml:"unit"` // Unit of a number question
+}
+
+// BuildOption is an answer option, written as "Text", "Text(color)" or a map with
+// text, color and optionally a fixed id
+type BuildOption struct {
+	ID    string `yaml:"id"`
+	Text  string `yaml:"text"`
+	Color string `yaml:"color"`
+}
+
+// UnmarshalYAML accepts a "Text(color)" scalar or a mapping
+func (o *BuildOption) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		o.Text, o.Color = splitOptionColor(node.Value)
+		return nil
+	}
+	type plain BuildOption
+	return node.Decode((*plain)(o))
+}
+
+// buildAnswerTypes maps the type names of the authoring format to answer types
+var buildAnswerTypes = map[string]string{
+	"yesno":     "yesnona",
+	"choice":    "multiplechoice",
+	"text":      "freetext",
+	"number":    "numeric",
+	"static":    "statictext",
+	"rating":    "rating",
+	"date":      "date",
+	"time":      "time",
+	"duration":  "duration",
+	"signature": "signature",
+}
+
+// OptionColors are the color names that can be used for options
+var OptionColors = map[string]string{
+	"green":  "#33cc66",
+	"red":    "#f84143",
+	"orange": "#ff9800",
+	"yellow": "#ffc107",
+	"blue":   "#2196f3",
+	"grey":   "#9e9e9e",
+	"gray":   "#9e9e9e",
+}
+
+// yesNoStylingKeys are the styling keys of yes/no questions, in option order
+var yesNoStylingKeys = []string{"YES", "NO", "N/A"}
+
+var (
+	optionColorRe = regexp.MustCompile(`^(.*?)\s*\(\s*([^()]+?)\s*\)$`)
+	hexColorRe    = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+)
+
+// splitOptionColor splits "Not OK(red)" into text and color. Parentheses that
+// don't hold a color name or hex color are part of the text.
+func splitOptionColor(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	m := optionColorRe.FindStringSubmatch(s)
+	if m == nil || m[1] == "" {
+		return s, ""
+	}
+	if _, ok := OptionColors[strings.ToLower(m[2])]; ok || hexColorRe.MatchString(m[2]) {
+		return m[1], m[2]
+	}
+	return s, ""
+}
+
+// LoadTemplateBuildFile reads a YAML template authoring file and compiles it
+func LoadTemplateBuildFile(path string) ([]TemplateCategory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading questions file: %w", err)
+	}
+	return BuildTemplateQuestions(data)
+}
+
+// BuildTemplateQuestions compiles the YAML template authoring format into template
+// categories: it generates option IDs, rich options and styling, and validates the
+// result with ValidateTemplateQuestions
+func BuildTemplateQuestions(data []byte) ([]TemplateCategory, error) {
+	categories, err := compileTemplateQuestions(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateTemplateQuestions(categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// compileTemplateQuestions compiles the YAML template authoring format without
+// validating the result
+func compileTemplateQuestions(data []byte) ([]TemplateCategory, error) {
+	var source []BuildCategory
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&source); err != nil {
+		return nil, fmt.Errorf("parsing questions file: %w", err)
+	}
+
+	var errs []string
+	categories := make([]TemplateCategory, 0, len(source))
+	for ci, sc := range source {
+		category := TemplateCategory{
+			CategoryName: strings.TrimSpace(sc.Category),
+			Questions:    []TemplateQuestion{},
+			Settings:     TemplateCategorySettings{Duplicate: sc.Duplicate},
+		}
+		for qi, sq := range sc.Questions {
+			q, err := buildQuestion(category.CategoryName, sq)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("category %d, question %d: %v", ci+1, qi+1, err))
+				continue
+			}
+			category.Questions = append(category.Questions, q)
+		}
+		categories = append(categories, category)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("build errors:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+	return categories, nil
+}
+
+// buildQuestion compiles one question
+func buildQuestion(category string, sq BuildQuestion) (TemplateQuestion, error) {
+	typeName := strings.ToLower(strings.TrimSpace(sq.Type))
+	if typeName == "" {
+		typeName = "yesno"
+	}
+	answerType, ok := buildAnswerTypes[typeName]
+	if !ok && validAnswerTypes[typeName] {
+		answerType, ok = typeName, true
+	}
+	if !ok {
+		return TemplateQuestion{}, fmt.Errorf("unknown type %q (use yesno, choice, text, number, rating, date, time, duration, signature or static)", sq.Type)
+	}
+
+	q := TemplateQuestion{
+		Question:    strings.TrimSpace(sq.Question),
+		Description: strings.TrimSpace(sq.Description),
+		Answer:      []interface{}{},
+		Ticket:      []interface{}{},
+		Settings: TemplateQuestionSettings{
+			AnswerType:     answerType,
+			TicketRequired: sq.Ticket,
+			Required:       sq.Required,
+		},
+	}
+
+	if sq.Multiple && answerType != "multiplechoice" {
+		return q, fmt.Errorf("multiple is only allowed for choice questions")
+	}
+	if (sq.Min != nil || sq.Max != nil) && answerType != "numeric" && answerType != "rating" {
+		return q, fmt.Errorf("min and max are only allowed for number and rating questions")
+	}
+	if sq.Unit != "" && answerType != "numeric" {
+		return q, fmt.Errorf("unit is only allowed for number questions")
+	}
+	q.Settings.Min, q.Settings.Max = sq.Min, sq.Max
+	q.Settings.Unit = strings.TrimSpace(sq.Unit)
+
+	switch answerType {
+	case "multiplechoice":
+		if len(sq.Options) == 0 {
+			return q, fmt.Errorf("choice question needs options")
+		}
+		q.Settings.Choice = "single"
+		if sq.Multiple {
+			q.Settings.Choice = "multiple"
+		}
+		seen := make(map[string]bool)
+		for i, opt := range sq.Options {
+			text := strings.TrimSpace(opt.Text)
+			if text == "" {
+				return q, fmt.Errorf("option %d has no text", i+1)
+			}
+			id := opt.ID
+			if id == "" {
+				id = optionID(category, q.Question, i, text)
+			}
+			if seen[id] {
+				return q, fmt.Errorf("option %d: duplicate id %q", i+1, id)
+			}
+			seen[id] = true
+			q.Settings.Answer = append(q.Settings.Answer, id)
+			q.Settings.RichOptions = append(q.Settings.RichOptions, RichOption{ID: id, Text: text, Type: "textselect"})
+		}
+		styling, err := buildStyling(q.Settings.Answer, sq.Options)
+		if err != nil {
+			return q, err
+		}
+		q.Settings.Styling = styling
+
+	case "yesnona":
+		if len(sq.Options) > len(yesNoStylingKeys) {
+			return q, fmt.Errorf("yesno question takes at most 3 options (labels for yes, no and n/a)")
+		}
+		if len(sq.Options) > 0 {
+			styling, err := buildStyling(yesNoStylingKeys[:len(sq.Options)], sq.Options)
+			if err != nil {
+				return q, err
+			}
+			q.Settings.Styling = styling
+		}
+
+	default:
+		if len(sq.Options) > 0 {
+			return q, fmt.Errorf("options are only allowed for choice and yesno questions")
+		}
+	}
+	return q, nil
+}
+
+// buildStyling returns the styling for options, keyed by keys
+func buildStyling(keys []string, options []BuildOption) (*QuestionStyling, error) {
+	styling := &QuestionStyling{Options: make(map[string]StylingOption, len(options))}
+	for i, opt := range options {
+		style := StylingOption{Label: strings.TrimSpace(opt.Text), SortIndex: i + 1}
+		if opt.Color != "" {
+			color, err := parseOptionColor(opt.Color)
+			if err != nil {
+				return nil, fmt.Errorf("option %d: %w", i+1, err)
+			}
+			style.BackgroundColor = color
+			style.Color = "#fff"
+		}
+		styling.Options[keys[i]] = style
+	}
+	return styling, nil
+}
+
+// parseOptionColor returns the hex color of a color name or hex color
+func parseOptionColor(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if color, ok := OptionColors[strings.ToLower(s)]; ok {
+		return color, nil
+	}
+	if hexColorRe.MatchString(s) {
+		return strings.ToLower(s), nil
+	}
+	return "", fmt.Errorf("unknown color %q (use green, red, orange, yellow, blue, grey or #rrggbb)", s)
+}
+
+// optionID generates a stable option ID from the position and text of an option,
+// so rebuilding an unchanged file gives the same IDs
+func optionID(category, question string, index int, text string) string {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s\x00%s\x00%d\x00%s", category, question, index, text)
+	return fmt.Sprintf("%08x", h.Sum32())
+}
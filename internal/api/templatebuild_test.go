@@ -0,0 +1,130 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildTemplateQuestions(t *testing.T) {
+	data := []byte(`
+- category: Fire safety
+  questions:
+    - q: "Exits clear?"
+      type: choice
+      multiple: true
+      ticket: true
+      options: [OK(green), Not OK(red), Other (explain), {id: fixed, text: Blocked, color: "#FF0000"}]
+    - q: Extinguisher inspected?
+      required: true
+      options: [Good(green), Bad(red)]
+- category: Housekeeping
+  duplicate: true
+  questions:
+    - q: Tidiness
+      type: rating
+      max: 10
+    - q: Remarks
+      type: freetext
+`)
+
+	categories, err := BuildTemplateQuestions(data)
+	if err != nil {
+		t.Fatalf("BuildTemplateQuestions: %v", err)
+	}
+	if len(categories) != 2 || !categories[1].Settings.Duplicate {
+		t.Fatalf("categories = %+v", categories)
+	}
+
+	choice := categories[0].Questions[0].Settings
+	if choice.AnswerType != "multiplechoice" || choice.Choice != "multiple" || !choice.TicketRequired {
+		t.Errorf("choice settings = %+v", choice)
+	}
+	var texts []string
+	for i, opt := range choice.RichOptions {
+		texts = append(texts, opt.Text)
+		if opt.Type != "textselect" || opt.ID != choice.Answer[i] {
+			t.Errorf("option %d = %+v, answer id %q", i, opt, choice.Answer[i])
+		}
+	}
+	if want := []string{"OK", "Not OK", "Other (explain)", "Blocked"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("option texts = %v, want %v", texts, want)
+	}
+	if choice.Answer[3] != "fixed" {
+		t.Errorf("fixed id = %q", choice.Answer[3])
+	}
+	notOK := choice.Styling.Options[choice.Answer[1]]
+	if notOK.BackgroundColor != "#f84143" || notOK.Label != "Not OK" || notOK.SortIndex != 2 {
+		t.Errorf("Not OK styling = %+v", notOK)
+	}
+	if got := choice.Styling.Options["fixed"].BackgroundColor; got != "#ff0000" {
+		t.Errorf("hex color = %q", got)
+	}
+	if got := choice.Styling.Options[choice.Answer[2]].BackgroundColor; got != "" {
+		t.Errorf("option without color got %q", got)
+	}
+
+	if rating := categories[1].Questions[0].Settings; rating.Max == nil || *rating.Max != 10 || rating.Min != nil {
+		t.Errorf("rating settings = %+v", rating)
+	}
+
+	yesno := categories[0].Questions[1].Settings
+	if yesno.AnswerType != "yesnona" || !yesno.Required {
+		t.Errorf("yesno settings = %+v", yesno)
+	}
+	if got := yesno.Styling.Options["NO"]; got.Label != "Bad" || got.BackgroundColor != "#f84143" {
+		t.Errorf("NO styling = %+v", got)
+	}
+
+	// Rebuilding gives the same IDs
+	again, err := BuildTemplateQuestions(data)
+	if err != nil {
+		t.Fatalf("BuildTemplateQuestions: %v", err)
+	}
+	if !reflect.DeepEqual(again[0].Questions[0].Settings.Answer, choice.Answer) {
+		t.Error("option IDs are not stable")
+	}
+}
+
+func TestBuildTemplateQuestionsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"unknown field", "- category: A\n  questions:\n    - q: B\n      typo: x\n", "field typo not found"},
+		{"unknown type", "- category: A\n  questions:\n    - q: B\n      type: choise\n", `unknown type "choise"`},
+		{"choice without options", "- category: A\n  questions:\n    - q: B\n      type: choice\n", "needs options"},
+		{"unknown color", "- category: A\n  questions:\n    - q: B\n      type: choice\n      options: [{text: X, color: pink}]\n", `unknown color "pink"`},
+		{"options on text", "- category: A\n  questions:\n    - q: B\n      type: text\n      options: [X]\n", "only allowed for choice and yesno"},
+		{"min on text", "- category: A\n  questions:\n    - q: B\n      type: text\n      min: 1\n", "only allowed for number and rating"},
+		{"validation", "- category: A\n  questions:\n    - q: \"\"\n", "question text is required"},
+		{"rating scale", "- category: A\n  questions:\n    - q: B\n      type: rating\n      min: 5\n      max: 3\n", "rating scale 5-3 is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildTemplateQuestions([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitOptionColor(t *testing.T) {
+	tests := []struct {
+		input, text, color string
+	}{
+		{"OK", "OK", ""},
+		{"Not OK(red)", "Not OK", "red"},
+		{"Not OK (#f00)", "Not OK", "#f00"},
+		{"Other (explain)", "Other (explain)", ""},
+		{"(red)", "(red)", ""},
+	}
+	for _, tt := range tests {
+		text, color := splitOptionColor(tt.input)
+		if text != tt.text || color != tt.color {
+			t.Errorf("splitOptionColor(%q) = %q, %q, want %q, %q", tt.input, text, color, tt.text, tt.color)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CopyAuditTemplateOptions contains options for copying an audit template
+type CopyAuditTemplateOptions struct {
+	SourceDatabase string
+	TemplateID     string
+	TargetDatabase string
+	GroupID        string // Template group in the target project
+	Name           string // New name (optional, defaults to the source name)
+	Publish        bool
+}
+
+// CopiedTemplate describes a template created by CopyAuditTemplate
+type CopiedTemplate struct {
+	SourceID string `json:"sourceId"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	GroupID  string `json:"groupId"`
+	Group    string `json:"group"`
+	// Names of attachments that couldn't be downloaded and weren't copied
+	DroppedAttachments []string `json:"droppedAttachments,omitempty"`
+}
+
+// CopyAuditTemplate copies an audit template, with its questions and settings, to a
+// template group in another (or the same) project. The copy is owned by the current
+// user and starts unpublished unless Publish is set. Attachments (such as images used
+// in questions) are downloaded and stored with the copy under the same names.
+func (c *Client) CopyAuditTemplate(opts CopyAuditTemplateOptions) (*CopiedTemplate, error) {
+	doc, err := c.GetDocument(opts.SourceDatabase, opts.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("getting template: %w", err)
+	}
+	if docType, _ := doc["type"].(string); docType != "IB.EdBundle.Document.AuditTemplate" {
+		return nil, fmt.Errorf("%s is not an audit template", opts.TemplateID)
+	}
+
+	email, err := c.Email()
+	if err != nil {
+		return nil, fmt.Errorf("getting user email: %w", err)
+	}
+
+	project, err := c.GetProject(opts.TargetDatabase)
+	if err != nil {
+		return nil, fmt.Errorf("getting project: %w", err)
+	}
+
+	group, err := c.GetTemplateGroup(opts.TargetDatabase, opts.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("getting template group: %w", err)
+	}
+
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	stubs := prepareTemplateCopy(doc, templateCopyTarget{
+		project:   project.CouchDbID,
+		groupID:   opts.GroupID,
+		groupName: group.Name,
+		name:      opts.Name,
+		email:     email,
+		publish:   opts.Publish,
+	}, now)
+
+	attachments, dropped := c.downloadInlineAttachments(opts.SourceDatabase, opts.TemplateID, stubs)
+	doc["_attachments"] = attachments
+
+	id, err := c.createDocument(opts.TargetDatabase, doc)
+	if err != nil {
+		return nil, fmt.Errorf("creating template: %w", err)
+	}
+
+	name, _ := doc["name"].(string)
+	return &CopiedTemplate{
+		SourceID:           opts.TemplateID,
+		ID:                 id,
+		Name:               name,
+		GroupID:            opts.GroupID,
+		Group:              group.Name,
+		DroppedAttachments: dropped,
+	}, nil
+}
+
+// FindOrCreateTemplateGroup returns the ID of the template group with the given
+// name (case-insensitive, not archived), creating it if there is none. The bool is
+// true when the group was created.
+func (c *Client) FindOrCreateTemplateGroup(database, name string) (string, bool, error) {
+	groups, _, err := c.ListTemplateGroups(ListGroupsOptions{Database: database, SearchName: name, Size: 100})
+	if err != nil {
+		return "", false, fmt.Errorf("listing template groups: %w", err)
+	}
+	for _, g := range groups {
+		if strings.EqualFold(strings.TrimSpace(g.Name), strings.TrimSpace(name)) && !g.Archived {
+			return firstID(g.CouchDbID, g.CouchID, g.ID), false, nil
+		}
+	}
+
+	id, err := c.CreateTemplateGroup(database, name)
+	if err != nil {
+		return "", false, fmt.Errorf("creating template group: %w", err)
+	}
+	return id, true, nil
+}
+
+// templateCopyTarget is where and by whom a template copy is created
+type templateCopyTarget struct {
+	project   string // CouchDB ID of the target project
+	groupID   string
+	groupName string
+	name      string
+	email     string
+	publish   bool
+}
+
+// downloadInlineAttachments downloads the attachments of a document, given by their
+// stubs, in the CouchDB inline attachment format. Attachments that can't be
+// downloaded are left out; their names are returned in order.
+func (c *Client) downloadInlineAttachments(database, docID string, stubs map[string]interface{}) (map[string]interface{}, []string) {
+	names := make([]string, 0, len(stubs))
+	for name := range stubs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attachments := make(map[string]interface{}, len(stubs))
+	var failed []string
+	for _, name := range names {
+		data, err := c.DownloadAttachment(database, docID, name)
+		if err != nil {
+			failed = append(failed, name)
+			continue
+		}
+		contentType := "application/octet-stream"
+		if stub, ok := stubs[name].(map[string]interface{}); ok {
+			if ct, ok := stub["content_type"].(string); ok && ct != "" {
+				contentType = ct
+			}
+		}
+		attachments[name] = map[string]interface{}{
+			"content_type": contentType,
+			"data":         base64.StdEncoding.EncodeToString(data),
+		}
+	}
+	return attachments, failed
+}
+
+// prepareTemplateCopy turns a template document into a new document for the target,
+// without attachments. It returns the attachment stubs of the source document.
+func prepareTemplateCopy(doc map[string]interface{}, target templateCopyTarget, now string) map[string]interface{} {
+	stubs, _ := doc["_attachments"].(map[string]interface{})
+
+	for _, key := range []string{"_id", "_rev", "_attachments", "couchDbId", "id", "database", "operation", "archived", "deleted"} {
+		delete(doc, key)
+	}
+
+	doc["_attachments"] = map[string]interface{}{}
+	doc["archived"] = nil
+	doc["project"] = target.project
+	doc["groupId"] = target.groupID
+	doc["group"] = target.groupName
+	if target.name != "" {
+		doc["name"] = target.name
+	}
+
+	doc["author"] = map[string]interface{}{
+		"type":  "IB.EdBundle.Document.Person",
+		"email": target.email,
+	}
+	doc["lastmodifier"] = map[string]interface{}{"email": target.email}
+
+	published := ""
+	if target.publish {
+		published = now
+	}
+	doc["isPublished"] = target.publish
+	doc["dates"] = map[string]interface{}{
+		"creationDate":     now,
+		"lastModifiedDate": now,
+		"publishedDate":    published,
+	}
+	doc["versionInfo"] = map[string]interface{}{}
+	doc["timeline"] = []interface{}{}
+
+	return stubs
+}
+
+func firstID(ids ...string) string {
+	for _, id := range ids {
+		if id != "" {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPrepareTemplateCopy(t *testing.T) {
+	questions := []interface{}{map[string]interface{}{"categoryName": "General"}}
+	doc := map[string]interface{}{
+		"_id":          "src-template",
+		"_rev":         "3-abc",
+		"_attachments": map[string]interface{}{"logo.png": map[string]interface{}{"stub": true}},
+		"type":         "IB.EdBundle.Document.AuditTemplate",
+		"name":         "Safety walk",
+		"project":      "src-project",
+		"groupId":      "src-group",
+		"group":        "Safety",
+		"author":       map[string]interface{}{"email": "someone@example.com"},
+		"isPublished":  true,
+		"archived":     "2026-01-01T00:00:00.000Z",
+		"operation":    []interface{}{map[string]interface{}{"author": "someone@example.com"}},
+		"questions":    questions,
+		"tags":         []interface{}{"safety"},
+	}
+
+	now := "2026-03-01T10:00:00.000Z"
+	stubs := prepareTemplateCopy(doc, templateCopyTarget{
+		project:   "dst-project",
+		groupID:   "dst-group",
+		groupName: "Safety",
+		email:     "me@example.com",
+	}, now)
+
+	if want := map[string]interface{}{"logo.png": map[string]interface{}{"stub": true}}; !reflect.DeepEqual(stubs, want) {
+		t.Errorf("stubs = %v, want %v", stubs, want)
+	}
+	if got := doc["_attachments"]; !reflect.DeepEqual(got, map[string]interface{}{}) {
+		t.Errorf("_attachments = %v, want none", got)
+	}
+	for _, key := range []string{"_id", "_rev", "operation"} {
+		if _, ok := doc[key]; ok {
+			t.Errorf("%s should be removed", key)
+		}
+	}
+	checks := map[string]interface{}{
+		"project":     "dst-project",
+		"groupId":     "dst-group",
+		"group":       "Safety",
+		"name":        "Safety walk",
+		"isPublished": false,
+		"archived":    nil,
+	}
+	for key, want := range checks {
+		if got := doc[key]; got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+	if got := doc["author"].(map[string]interface{})["email"]; got != "me@example.com" {
+		t.Errorf("author = %v", got)
+	}
+	if got := doc["dates"].(map[string]interface{})["publishedDate"]; got != "" {
+		t.Errorf("publishedDate = %v", got)
+	}
+	if !reflect.DeepEqual(doc["questions"], questions) || !reflect.DeepEqual(doc["tags"], []interface{}{"safety"}) {
+		t.Error("questions and tags should be kept")
+	}
+
+	published := map[string]interface{}{"name": "Walk"}
+	prepareTemplateCopy(published, templateCopyTarget{name: "Walk (copy)", publish: true}, now)
+	if published["name"] != "Walk (copy)" || published["isPublished"] != true {
+		t.Errorf("published copy = %v", published)
+	}
+	if got := published["dates"].(map[string]interface{})["publishedDate"]; got != now {
+		t.Errorf("publishedDate = %v, want %v", got, now)
+	}
+}
@@ -0,0 +1,367 @@
+package api
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kinds of template changes
+const (
+	TemplateAdded   = "added"   // Question or category only in the new version
+	TemplateRemoved = "removed" // Question or category only in the old version
+	TemplateMoved   = "moved"   // Question in another category, or category in another position
+	TemplateReorder = "reordered"
+	TemplateChanged = "changed" // Settings of the question changed
+)
+
+// TemplateChange is a difference between two versions of a template. Question is
+// empty for changes to a whole category.
+type TemplateChange struct {
+	Kind     string   `json:"kind"`
+	Category string   `json:"category"`
+	Question string   `json:"question,omitempty"`
+	Details  []string `json:"details,omitempty"`
+}
+
+// DiffTemplateQuestions compares two versions of template questions. Categories
+// are matched by name and questions by text (case-insensitive, without HTML);
+// within a category, questions that changed position relative to the others are
+// reported as reordered.
+func DiffTemplateQuestions(before, after []TemplateCategory) []TemplateChange {
+	changes := []TemplateChange{}
+
+	oldCats := make(map[string][]int)
+	for i, cat := range before {
+		key := textKey(cat.CategoryName)
+		oldCats[key] = append(oldCats[key], i)
+	}
+
+	// Pair categories in order
+	type pair struct{ old, new int }
+	var pairs []pair
+	matchedOld := make(map[int]bool)
+	var addedCats []int
+	for i, cat := range after {
+		key := textKey(cat.CategoryName)
+		if len(oldCats[key]) == 0 {
+			addedCats = append(addedCats, i)
+			continue
+		}
+		pairs = append(pairs, pair{oldCats[key][0], i})
+		matchedOld[oldCats[key][0]] = true
+		oldCats[key] = oldCats[key][1:]
+	}
+
+	// Categories that changed position among the common categories
+	oldOrder := make([]int, len(pairs))
+	for i, p := range pairs {
+		oldOrder[i] = p.old
+	}
+	inOrder := longestIncreasing(oldOrder)
+	for i, p := range pairs {
+		if !inOrder[i] {
+			changes = append(changes, TemplateChange{Kind: TemplateMoved, Category: PlainText(after[p.new].CategoryName),
+				Details: []string{fmt.Sprintf("position %d → %d", p.old+1, p.new+1)}})
+		}
+	}
+
+	// Questions of matched categories; unmatched questions are collected to detect
+	// questions that moved to another category
+	type loose struct {
+		category string
+		question TemplateQuestion
+		used     bool
+	}
+	var removed, added []*loose
+
+	for _, p := range pairs {
+		oldCat, newCat := before[p.old], after[p.new]
+		catName := PlainText(newCat.CategoryName)
+		if oldCat.Settings.Duplicate != newCat.Settings.Duplicate {
+			changes = append(changes, TemplateChange{Kind: TemplateChanged, Category: catName,
+				Details: []string{fmt.Sprintf("duplicate: %t → %t", oldCat.Settings.Duplicate, newCat.Settings.Duplicate)}})
+		}
+
+		oldQs := make(map[string][]int)
+		for i, q := range oldCat.Questions {
+			key := textKey(q.Question)
+			oldQs[key] = append(oldQs[key], i)
+		}
+		var qPairs []pair
+		usedOld := make(map[int]bool)
+		for i, q := range newCat.Questions {
+			key := textKey(q.Question)
+			if len(oldQs[key]) == 0 {
+				added = append(added, &loose{category: catName, question: q})
+				continue
+			}
+			qPairs = append(qPairs, pair{oldQs[key][0], i})
+			usedOld[oldQs[key][0]] = true
+			oldQs[key] = oldQs[key][1:]
+		}
+		for i, q := range oldCat.Questions {
+			if !usedOld[i] {
+				removed = append(removed, &loose{category: PlainText(oldCat.CategoryName), question: q})
+			}
+		}
+
+		order := make([]int, len(qPairs))
+		for i, qp := range qPairs {
+			order[i] = qp.old
+		}
+		inOrder := longestIncreasing(order)
+		for i, qp := range qPairs {
+			q := newCat.Questions[qp.new]
+			if !inOrder[i] {
+				changes = append(changes, TemplateChange{Kind: TemplateReorder, Category: catName, Question: PlainText(q.Question),
+					Details: []string{fmt.Sprintf("position %d → %d", qp.old+1, qp.new+1)}})
+			}
+			if details := questionChanges(oldCat.Questions[qp.old], q); len(details) > 0 {
+				changes = append(changes, TemplateChange{Kind: TemplateChanged, Category: catName, Question: PlainText(q.Question), Details: details})
+			}
+		}
+	}
+
+	for i, cat := range before {
+		if matchedOld[i] {
+			continue
+		}
+		changes = append(changes, TemplateChange{Kind: TemplateRemoved, Category: PlainText(cat.CategoryName),
+			Details: []string{questionCount(len(cat.Questions))}})
+		for _, q := range cat.Questions {
+			removed = append(removed, &loose{category: PlainText(cat.CategoryName), question: q})
+		}
+	}
+	for _, i := range addedCats {
+		cat := after[i]
+		changes = append(changes, TemplateChange{Kind: TemplateAdded, Category: PlainText(cat.CategoryName),
+			Details: []string{questionCount(len(cat.Questions))}})
+		for _, q := range cat.Questions {
+			added = append(added, &loose{category: PlainText(cat.CategoryName), question: q})
+		}
+	}
+
+	// Questions that moved between categories
+	for _, a := range added {
+		for _, r := range removed {
+			if r.used || textKey(r.question.Question) != textKey(a.question.Question) {
+				continue
+			}
+			r.used, a.used = true, true
+			details := append([]string{fmt.Sprintf("from %s", r.category)}, questionChanges(r.question, a.question)...)
+			changes = append(changes, TemplateChange{Kind: TemplateMoved, Category: a.category, Question: PlainText(a.question.Question), Details: details})
+			break
+		}
+	}
+	for _, r := range removed {
+		if !r.used {
+			changes = append(changes, TemplateChange{Kind: TemplateRemoved, Category: r.category, Question: PlainText(r.question.Question)})
+		}
+	}
+	for _, a := range added {
+		if !a.used {
+			changes = append(changes, TemplateChange{Kind: TemplateAdded, Category: a.category, Question: PlainText(a.question.Question),
+				Details: []string{"type " + a.question.Settings.AnswerType}})
+		}
+	}
+
+	return changes
+}
+
+// questionChanges describes the changed settings of a question
+func questionChanges(old, new TemplateQuestion) []string {
+	var details []string
+	o, n := old.Settings, new.Settings
+	if o.AnswerType != n.AnswerType {
+		details = append(details, fmt.Sprintf("answer type: %s → %s", o.AnswerType, n.AnswerType))
+	}
+	if o.AnswerType == "multiplechoice" && n.AnswerType == "multiplechoice" && o.Choice != n.Choice {
+		details = append(details, fmt.Sprintf("choice: %s → %s", o.Choice, n.Choice))
+	}
+	if o.Required != n.Required {
+		details = append(details, fmt.Sprintf("required: %t → %t", o.Required, n.Required))
+	}
+	if o.TicketRequired != n.TicketRequired {
+		details = append(details, fmt.Sprintf("ticket required: %t → %t", o.TicketRequired, n.TicketRequired))
+	}
+	if oldRange, newRange := valueRange(o), valueRange(n); oldRange != newRange {
+		details = append(details, fmt.Sprintf("range: %s → %s", oldRange, newRange))
+	}
+	if o.Unit != n.Unit {
+		details = append(details, fmt.Sprintf("unit: %s → %s", textOrNone(o.Unit), textOrNone(n.Unit)))
+	}
+	if PlainText(old.Description) != PlainText(new.Description) {
+		details = append(details, "description changed")
+	}
+	if old.Question != new.Question && textKey(old.Question) == textKey(new.Question) {
+		details = append(details, "question formatting changed")
+	}
+	return append(details, optionChanges(o, n)...)
+}
+
+// optionChanges compares the answer options (by text) and their colors
+func optionChanges(o, n TemplateQuestionSettings) []string {
+	oldOpts, newOpts := questionOptions(o), questionOptions(n)
+	if len(oldOpts) == 0 && len(newOpts) == 0 {
+		return nil
+	}
+
+	oldIndex := make(map[string]int, len(oldOpts))
+	for i, opt := range oldOpts {
+		oldIndex[textKey(opt.text)] = i
+	}
+	newIndex := make(map[string]int, len(newOpts))
+	for i, opt := range newOpts {
+		newIndex[textKey(opt.text)] = i
+	}
+
+	var details, addedOpts, removedOpts, recolored []string
+	var order []int
+	for _, opt := range newOpts {
+		i, ok := oldIndex[textKey(opt.text)]
+		if !ok {
+			addedOpts = append(addedOpts, opt.text)
+			continue
+		}
+		order = append(order, i)
+		if old := oldOpts[i]; !strings.EqualFold(old.color, opt.color) {
+			recolored = append(recolored, fmt.Sprintf("%s %s → %s", opt.text, textOrNone(old.color), textOrNone(opt.color)))
+		}
+	}
+	for _, opt := range oldOpts {
+		if _, ok := newIndex[textKey(opt.text)]; !ok {
+			removedOpts = append(removedOpts, opt.text)
+		}
+	}
+
+	if len(addedOpts) > 0 {
+		details = append(details, "options added: "+strings.Join(addedOpts, ", "))
+	}
+	if len(removedOpts) > 0 {
+		details = append(details, "options removed: "+strings.Join(removedOpts, ", "))
+	}
+	for _, inOrder := range longestIncreasing(order) {
+		if !inOrder {
+			details = append(details, "options reordered")
+			break
+		}
+	}
+	if len(recolored) > 0 {
+		details = append(details, "option colors: "+strings.Join(recolored, ", "))
+	}
+	return details
+}
+
+type questionOption struct {
+	text  string
+	color string
+}
+
+// questionOptions returns the answer options of a question in display order: the
+// rich options of a multiple choice question, or the styled yes/no/n.a. labels
+func questionOptions(s TemplateQuestionSettings) []questionOption {
+	style := func(key string) StylingOption {
+		if s.Styling == nil {
+			return StylingOption{}
+		}
+		if st, ok := s.Styling.Options[key]; ok {
+			return st
+		}
+		for k, st := range s.Styling.Options {
+			if strings.EqualFold(k, key) {
+				return st
+			}
+		}
+		return StylingOption{}
+	}
+
+	var options []questionOption
+	switch s.AnswerType {
+	case "multiplechoice":
+		for _, ro := range s.RichOptions {
+			options = append(options, questionOption{text: PlainText(ro.Text), color: style(ro.ID).BackgroundColor})
+		}
+	case "yesnona":
+		if s.Styling == nil {
+			return nil
+		}
+		for _, key := range yesNoStylingKeys {
+			st := style(key)
+			if st.Label == "" && st.BackgroundColor == "" {
+				continue
+			}
+			options = append(options, questionOption{text: firstID(st.Label, key), color: st.BackgroundColor})
+		}
+	}
+	return options
+}
+
+// longestIncreasing marks the elements that are part of a longest increasing
+// subsequence; the others are the ones that changed position
+func longestIncreasing(values []int) []bool {
+	n := len(values)
+	length := make([]int, n)
+	prev := make([]int, n)
+	best := -1
+	for i := range values {
+		length[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if values[j] < values[i] && length[j]+1 > length[i] {
+				length[i], prev[i] = length[j]+1, j
+			}
+		}
+		if best < 0 || length[i] > length[best] {
+			best = i
+		}
+	}
+
+	in := make([]bool, n)
+	for i := best; i >= 0; i = prev[i] {
+		in[i] = true
+	}
+	return in
+}
+
+var htmlTagRe = regexp.MustCompile(`<[^>]*>`)
+
+// PlainText strips HTML tags from a text stored as HTML and collapses whitespace
+func PlainText(s string) string {
+	s = html.UnescapeString(htmlTagRe.ReplaceAllString(s, " "))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// textKey is the key texts are matched by
+func textKey(s string) string {
+	return strings.ToLower(PlainText(s))
+}
+
+func questionCount(n int) string {
+	if n == 1 {
+		return "1 question"
+	}
+	return fmt.Sprintf("%d questions", n)
+}
+
+// valueRange describes the min and max of a numeric or rating question
+func valueRange(s TemplateQuestionSettings) string {
+	bound := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'g', -1, 64)
+	}
+	if s.Min == nil && s.Max == nil {
+		return "none"
+	}
+	return bound(s.Min) + ".." + bound(s.Max)
+}
+
+func textOrNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
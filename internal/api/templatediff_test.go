@@ -0,0 +1,101 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func diffYesNo(text string) TemplateQuestion {
+	return TemplateQuestion{Question: text, Settings: TemplateQuestionSettings{AnswerType: "yesnona"}}
+}
+
+func diffChoice(text string, options ...string) TemplateQuestion {
+	q := TemplateQuestion{Question: text, Settings: TemplateQuestionSettings{AnswerType: "multiplechoice", Choice: "single"}}
+	for _, o := range options {
+		q.Settings.Answer = append(q.Settings.Answer, o)
+		q.Settings.RichOptions = append(q.Settings.RichOptions, RichOption{ID: o, Text: o, Type: "textselect"})
+	}
+	return q
+}
+
+func TestDiffTemplateQuestions(t *testing.T) {
+	before := []TemplateCategory{
+		{CategoryName: "Entrance", Questions: []TemplateQuestion{
+			diffYesNo("Door closed?"),
+			diffYesNo("Lights on?"),
+			diffYesNo("Sign visible?"),
+			diffChoice("Floor", "Clean", "Dirty"),
+		}},
+		{CategoryName: "Roof", Questions: []TemplateQuestion{
+			diffYesNo("Gutters clear?"),
+			diffYesNo("Antenna fixed?"),
+		}},
+		{CategoryName: "Old", Questions: []TemplateQuestion{diffYesNo("Obsolete?")}},
+	}
+
+	numeric := diffYesNo("Gutters clear?")
+	numeric.Settings.AnswerType = "numeric"
+	floor := diffChoice("Floor", "Dirty", "Clean", "Wet")
+	floor.Settings.TicketRequired = true
+
+	after := []TemplateCategory{
+		{CategoryName: "entrance", Questions: []TemplateQuestion{
+			diffYesNo("Lights on?"),
+			diffYesNo("<b>Sign</b>  visible?"),
+			diffYesNo("Door closed?"),
+			floor,
+			diffYesNo("Antenna fixed?"),
+		}},
+		{CategoryName: "Roof", Questions: []TemplateQuestion{
+			numeric,
+			diffYesNo("Solar panels?"),
+		}},
+	}
+
+	want := []TemplateChange{
+		{Kind: TemplateChanged, Category: "entrance", Question: "Sign visible?", Details: []string{"question formatting changed"}},
+		{Kind: TemplateReorder, Category: "entrance", Question: "Door closed?", Details: []string{"position 1 → 3"}},
+		{Kind: TemplateChanged, Category: "entrance", Question: "Floor", Details: []string{"ticket required: false → true", "options added: Wet", "options reordered"}},
+		{Kind: TemplateChanged, Category: "Roof", Question: "Gutters clear?", Details: []string{"answer type: yesnona → numeric"}},
+		{Kind: TemplateRemoved, Category: "Old", Details: []string{"1 question"}},
+		{Kind: TemplateMoved, Category: "entrance", Question: "Antenna fixed?", Details: []string{"from Roof"}},
+		{Kind: TemplateRemoved, Category: "Old", Question: "Obsolete?"},
+		{Kind: TemplateAdded, Category: "Roof", Question: "Solar panels?", Details: []string{"type yesnona"}},
+	}
+
+	got := DiffTemplateQuestions(before, after)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffTemplateQuestions() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := DiffTemplateQuestions(before, before); len(got) != 0 {
+		t.Errorf("DiffTemplateQuestions() of equal templates = %+v, want none", got)
+	}
+}
+
+func TestDiffTemplateCategoryOrder(t *testing.T) {
+	a := TemplateCategory{CategoryName: "A"}
+	b := TemplateCategory{CategoryName: "B"}
+	c := TemplateCategory{CategoryName: "C"}
+
+	got := DiffTemplateQuestions([]TemplateCategory{a, b, c}, []TemplateCategory{c, a, b})
+	want := []TemplateChange{{Kind: TemplateMoved, Category: "C", Details: []string{"position 3 → 1"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffTemplateQuestions() = %+v, want %+v", got, want)
+	}
+}
+
+func TestOptionChangesYesNoStyling(t *testing.T) {
+	styled := func(noColor string) TemplateQuestionSettings {
+		return TemplateQuestionSettings{AnswerType: "yesnona", Styling: &QuestionStyling{Options: map[string]StylingOption{
+			"YES": {Label: "OK", BackgroundColor: "#33cc66"},
+			"NO":  {Label: "Not OK", BackgroundColor: noColor},
+		}}}
+	}
+
+	got := optionChanges(styled("#f84143"), styled("#ff9800"))
+	want := []string{"option colors: Not OK #f84143 → #ff9800"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("optionChanges() = %v, want %v", got, want)
+	}
+}
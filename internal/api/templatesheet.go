@@ -0,0 +1,387 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TemplateSheetColumns are the columns of a template checklist spreadsheet. Only
+// category and question are needed to import a checklist; the others are optional.
+var TemplateSheetColumns = []string{"category", "question", "description", "answer type", "options", "ticket required", "required", "min", "max", "unit", "duplicate"}
+
+// multipleSuffix marks choice questions that allow more than one option
+const multipleSuffix = " (multiple)"
+
+// TemplateSheet returns the questions of a template as spreadsheet rows, starting
+// with a header row. Answer types use the names of the YAML authoring format and
+// options are written as "Text(color)" separated by ";". Categories without
+// questions get a row without a question. Each row repeats whether its category
+// can be duplicated.
+func TemplateSheet(categories []TemplateCategory) [][]interface{} {
+	header := make([]interface{}, len(TemplateSheetColumns))
+	for i, name := range TemplateSheetColumns {
+		header[i] = name
+	}
+	rows := [][]interface{}{header}
+
+	for _, cat := range categories {
+		duplicate := yesOrEmpty(cat.Settings.Duplicate)
+		if len(cat.Questions) == 0 {
+			row := make([]interface{}, len(TemplateSheetColumns))
+			row[0], row[len(row)-1] = PlainText(cat.CategoryName), duplicate
+			rows = append(rows, row)
+			continue
+		}
+		for _, q := range cat.Questions {
+			s := q.Settings
+			rows = append(rows, []interface{}{
+				PlainText(cat.CategoryName),
+				PlainText(q.Question),
+				PlainText(q.Description),
+				sheetAnswerType(s),
+				strings.Join(sheetOptions(s), "; "),
+				yesOrEmpty(s.TicketRequired),
+				yesOrEmpty(s.Required),
+				floatOrNil(s.Min),
+				floatOrNil(s.Max),
+				s.Unit,
+				duplicate,
+			})
+		}
+	}
+	return rows
+}
+
+// sheetAnswerType returns the authoring format name of an answer type
+func sheetAnswerType(s TemplateQuestionSettings) string {
+	for name, answerType := range buildAnswerTypes {
+		if answerType == s.AnswerType {
+			if answerType == "multiplechoice" && s.Choice == "multiple" {
+				return name + multipleSuffix
+			}
+			return name
+		}
+	}
+	return s.AnswerType
+}
+
+// sheetOptions returns the options of a question as "Text(color)"
+func sheetOptions(s TemplateQuestionSettings) []string {
+	style := func(key string) StylingOption {
+		if s.Styling == nil {
+			return StylingOption{}
+		}
+		return s.Styling.Options[key]
+	}
+
+	var options []string
+	switch s.AnswerType {
+	case "multiplechoice":
+		for _, ro := range s.RichOptions {
+			options = append(options, sheetOption(PlainText(ro.Text), style(ro.ID).BackgroundColor))
+		}
+	case "yesnona":
+		if s.Styling == nil {
+			return nil
+		}
+		// Labels are positional (yes, no, n/a), so fill in the defaults before a
+		// styled option
+		defaults := []string{"Yes", "No", "N/A"}
+		last := -1
+		for i, key := range yesNoStylingKeys {
+			if _, ok := s.Styling.Options[key]; ok {
+				last = i
+			}
+		}
+		for i := 0; i <= last; i++ {
+			st := style(yesNoStylingKeys[i])
+			options = append(options, sheetOption(firstID(st.Label, defaults[i]), st.BackgroundColor))
+		}
+	}
+	return options
+}
+
+// sheetOption writes an option with its color, using the color name when there is one
+func sheetOption(text, color string) string {
+	if color == "" {
+		return text
+	}
+	names := make([]string, 0, len(OptionColors))
+	for name := range OptionColors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name != "gray" && strings.EqualFold(OptionColors[name], color) {
+			return text + "(" + name + ")"
+		}
+	}
+	return text + "(" + color + ")"
+}
+
+func yesOrEmpty(b bool) string {
+	if b {
+		return "yes"
+	}
+	return ""
+}
+
+func floatOrNil(v *float64) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// ParseTemplateSheet converts checklist rows (see TemplateSheetColumns), starting
+// with a header row, into template categories. Headers are matched
+// case-insensitively; rows of the same category don't need to be adjacent, and a
+// category can be duplicated when any of its rows says so. The result is
+// validated with ValidateTemplateQuestions.
+func ParseTemplateSheet(rows [][]string) ([]TemplateCategory, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("checklist is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		key = strings.NewReplacer("-", " ", "_", " ").Replace(key)
+		if key == "type" {
+			key = "answer type"
+		}
+		columns[key] = i
+	}
+	for _, name := range TemplateSheetColumns[:2] {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("checklist has no %q column (columns: %s)", name, strings.Join(TemplateSheetColumns, ", "))
+		}
+	}
+	cell := func(row []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var source []BuildCategory
+	categoryIndex := make(map[string]int)
+	var errs []string
+	for r, row := range rows[1:] {
+		line := r + 2
+		category, question := cell(row, "category"), cell(row, "question")
+		if category == "" && question == "" {
+			continue
+		}
+		if category == "" {
+			errs = append(errs, fmt.Sprintf("row %d: category is required", line))
+			continue
+		}
+
+		ci, ok := categoryIndex[strings.ToLower(category)]
+		if !ok {
+			ci = len(source)
+			categoryIndex[strings.ToLower(category)] = ci
+			source = append(source, BuildCategory{Category: category})
+		}
+		duplicate, err := parseSheetBool(cell(row, "duplicate"))
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("row %d: duplicate: %v", line, err))
+			continue
+		}
+		source[ci].Duplicate = source[ci].Duplicate || duplicate
+		if question == "" {
+			continue
+		}
+
+		bq, err := sheetQuestion(question, func(name string) string { return cell(row, name) })
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("row %d: %v", line, err))
+			continue
+		}
+		source[ci].Questions = append(source[ci].Questions, bq)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("checklist errors:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+
+	categories := make([]TemplateCategory, 0, len(source))
+	for _, sc := range source {
+		category := TemplateCategory{
+			CategoryName: sc.Category,
+			Questions:    []TemplateQuestion{},
+			Settings:     TemplateCategorySettings{Duplicate: sc.Duplicate},
+		}
+		for _, sq := range sc.Questions {
+			q, err := buildQuestion(sc.Category, sq)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s / %s: %v", sc.Category, sq.Question, err))
+				continue
+			}
+			category.Questions = append(category.Questions, q)
+		}
+		categories = append(categories, category)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("checklist errors:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+
+	if err := ValidateTemplateQuestions(categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// MergeTemplateSheet carries over what a checklist can't hold from the current
+// questions of a template, so updating a template from its exported checklist
+// doesn't lose anything. Questions are matched by category and question text;
+// formatted (HTML) texts are kept when their plain text didn't change, and
+// options that are still there keep their ID and image, so answers given to
+// earlier versions of the template keep their meaning.
+func MergeTemplateSheet(current, categories []TemplateCategory) {
+	currentCategories := make(map[string]TemplateCategory, len(current))
+	for _, cat := range current {
+		if _, ok := currentCategories[textKey(cat.CategoryName)]; !ok {
+			currentCategories[textKey(cat.CategoryName)] = cat
+		}
+	}
+
+	for ci := range categories {
+		cat := &categories[ci]
+		old, ok := currentCategories[textKey(cat.CategoryName)]
+		if !ok {
+			continue
+		}
+		cat.CategoryName = keepFormatted(old.CategoryName, cat.CategoryName)
+
+		oldQuestions := make(map[string]TemplateQuestion, len(old.Questions))
+		for _, q := range old.Questions {
+			if _, ok := oldQuestions[textKey(q.Question)]; !ok {
+				oldQuestions[textKey(q.Question)] = q
+			}
+		}
+		for qi := range cat.Questions {
+			q := &cat.Questions[qi]
+			oq, ok := oldQuestions[textKey(q.Question)]
+			if !ok {
+				continue
+			}
+			q.Question = keepFormatted(oq.Question, q.Question)
+			q.Description = keepFormatted(oq.Description, q.Description)
+			if q.Settings.AnswerType == "multiplechoice" && oq.Settings.AnswerType == "multiplechoice" {
+				keepOptionIDs(&q.Settings, oq.Settings)
+			}
+		}
+	}
+}
+
+// keepFormatted returns the old text if it reads the same as text
+func keepFormatted(old, text string) string {
+	if old != "" && PlainText(old) == PlainText(text) {
+		return old
+	}
+	return text
+}
+
+// keepOptionIDs gives options that match an old option by text the old ID, image
+// and formatted text, and renames their answer and styling keys
+func keepOptionIDs(s *TemplateQuestionSettings, old TemplateQuestionSettings) {
+	oldOptions := make(map[string]RichOption, len(old.RichOptions))
+	for _, ro := range old.RichOptions {
+		if _, ok := oldOptions[textKey(ro.Text)]; !ok {
+			oldOptions[textKey(ro.Text)] = ro
+		}
+	}
+
+	renamed := make(map[string]string)
+	kept := make(map[string]bool)
+	for i := range s.RichOptions {
+		ro := &s.RichOptions[i]
+		oro, ok := oldOptions[textKey(ro.Text)]
+		if !ok || kept[oro.ID] {
+			continue
+		}
+		kept[oro.ID] = true
+		renamed[ro.ID] = oro.ID
+		ro.ID, ro.Image = oro.ID, oro.Image
+		ro.Text = keepFormatted(oro.Text, ro.Text)
+	}
+	if len(renamed) == 0 {
+		return
+	}
+
+	for i, id := range s.Answer {
+		if oldID, ok := renamed[id]; ok {
+			s.Answer[i] = oldID
+		}
+	}
+	if s.Styling != nil {
+		options := make(map[string]StylingOption, len(s.Styling.Options))
+		for id, style := range s.Styling.Options {
+			if oldID, ok := renamed[id]; ok {
+				id = oldID
+			}
+			options[id] = style
+		}
+		s.Styling.Options = options
+	}
+}
+
+// sheetQuestion converts the cells of a checklist row into the authoring format
+func sheetQuestion(question string, cell func(string) string) (BuildQuestion, error) {
+	bq := BuildQuestion{
+		Question:    question,
+		Description: cell("description"),
+		Type:        cell("answer type"),
+		Unit:        cell("unit"),
+	}
+
+	if strings.HasSuffix(strings.ToLower(bq.Type), multipleSuffix) {
+		bq.Type = strings.TrimSpace(bq.Type[:len(bq.Type)-len(multipleSuffix)])
+		bq.Multiple = true
+	}
+
+	var err error
+	if bq.Ticket, err = parseSheetBool(cell("ticket required")); err != nil {
+		return bq, fmt.Errorf("ticket required: %w", err)
+	}
+	if bq.Required, err = parseSheetBool(cell("required")); err != nil {
+		return bq, fmt.Errorf("required: %w", err)
+	}
+	for _, bound := range []struct {
+		name   string
+		target **float64
+	}{{"min", &bq.Min}, {"max", &bq.Max}} {
+		value := cell(bound.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+		if err != nil {
+			return bq, fmt.Errorf("%s: invalid number %q", bound.name, value)
+		}
+		*bound.target = &n
+	}
+
+	for _, opt := range strings.FieldsFunc(cell("options"), func(r rune) bool { return r == ';' || r == '\n' }) {
+		if opt = strings.TrimSpace(opt); opt != "" {
+			text, color := splitOptionColor(opt)
+			bq.Options = append(bq.Options, BuildOption{Text: text, Color: color})
+		}
+	}
+	return bq, nil
+}
+
+// parseSheetBool reads a yes/no cell; empty is no
+func parseSheetBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "no", "n", "false", "0", "-":
+		return false, nil
+	case "yes", "y", "true", "1", "x":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid value %q (use yes or no)", s)
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTemplateSheet(t *testing.T) {
+	rows := [][]string{
+		{"\ufeffCategory", "Question", "Description", "Type", "Options", "Ticket-Required", "Max"},
+		{"Fire safety", "Exits clear?", "", "yesno", "OK(green); Not OK(red)", "yes"},
+		{"Fire safety", "Extinguishers", "Check the seal", "choice (multiple)", "Present; Sealed(#00ff00)\nInspected"},
+		{"", "", "", "", ""},
+		{"Housekeeping", "Tidiness", "", "rating", "", "", "10"},
+		{"fire safety", "Remarks", "", "text"},
+		{"Empty"},
+	}
+
+	categories, err := ParseTemplateSheet(rows)
+	if err != nil {
+		t.Fatalf("ParseTemplateSheet() error = %v", err)
+	}
+
+	var names []string
+	for _, cat := range categories {
+		names = append(names, fmt.Sprintf("%s:%d", cat.CategoryName, len(cat.Questions)))
+	}
+	if want := []string{"Fire safety:3", "Housekeeping:1", "Empty:0"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("categories = %v, want %v", names, want)
+	}
+
+	exits := categories[0].Questions[0].Settings
+	if exits.AnswerType != "yesnona" || !exits.TicketRequired || exits.Styling.Options["NO"].Label != "Not OK" {
+		t.Errorf("exits settings = %+v", exits)
+	}
+
+	choice := categories[0].Questions[1]
+	if choice.Settings.Choice != "multiple" || len(choice.Settings.RichOptions) != 3 || choice.Description != "Check the seal" {
+		t.Errorf("choice question = %+v", choice)
+	}
+	if got := choice.Settings.Styling.Options[choice.Settings.Answer[1]].BackgroundColor; got != "#00ff00" {
+		t.Errorf("option color = %q", got)
+	}
+
+	if max := categories[1].Questions[0].Settings.Max; max == nil || *max != 10 {
+		t.Errorf("rating max = %v", max)
+	}
+}
+
+func TestParseTemplateSheetErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]string
+		want string
+	}{
+		{"no question column", [][]string{{"category", "text"}}, `no "question" column`},
+		{"missing category", [][]string{{"category", "question"}, {"", "Q"}}, "row 2: category is required"},
+		{"bad ticket value", [][]string{{"category", "question", "ticket required"}, {"A", "Q", "maybe"}}, `row 2: ticket required: invalid value "maybe"`},
+		{"bad number", [][]string{{"category", "question", "type", "min"}, {"A", "Q", "number", "low"}}, `min: invalid number "low"`},
+		{"unknown type", [][]string{{"category", "question", "type"}, {"A", "Q", "checkbox"}}, `unknown type "checkbox"`},
+		{"no categories", [][]string{{"category", "question"}}, "at least 1 category"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTemplateSheet(tt.rows)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateSheetRoundTrip(t *testing.T) {
+	source := []byte(`
+- category: Fire safety
+  questions:
+    - q: Exits clear?
+      type: choice
+      multiple: true
+      ticket: true
+      options: [OK(green), Not OK(#123456), Other]
+    - q: Extinguisher inspected?
+      required: true
+      options: [Good, Bad(red)]
+    - q: Gap
+      type: number
+      min: 0
+      max: 12.5
+      unit: mm
+- category: Empty
+  duplicate: true
+`)
+	categories, err := compileTemplateQuestions(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sheet := TemplateSheet(categories)
+	if got := sheet[1][4]; got != "OK(green); Not OK(#123456); Other" {
+		t.Errorf("options cell = %q", got)
+	}
+	if got := sheet[2][4]; got != "Good; Bad(red)" {
+		t.Errorf("yes/no options cell = %q", got)
+	}
+
+	rows := make([][]string, len(sheet))
+	for i, row := range sheet {
+		for _, v := range row {
+			if v == nil {
+				v = ""
+			}
+			rows[i] = append(rows[i], fmt.Sprint(v))
+		}
+	}
+	again, err := ParseTemplateSheet(rows)
+	if err != nil {
+		t.Fatalf("ParseTemplateSheet() error = %v", err)
+	}
+	if changes := DiffTemplateQuestions(categories, again); len(changes) != 0 {
+		t.Errorf("round trip changed the template: %+v", changes)
+	}
+	if again[0].Settings.Duplicate || !again[1].Settings.Duplicate {
+		t.Errorf("duplicate = %v, %v, want false, true", again[0].Settings.Duplicate, again[1].Settings.Duplicate)
+	}
+}
+
+func TestMergeTemplateSheet(t *testing.T) {
+	current := []TemplateCategory{{
+		CategoryName: "<b>Fire</b> safety",
+		Questions: []TemplateQuestion{{
+			Question:    "<p>Exits clear?</p>",
+			Description: "<p>Check all exits</p>",
+			Settings: TemplateQuestionSettings{
+				AnswerType:  "multiplechoice",
+				Answer:      []string{"opt-ok", "opt-bad"},
+				RichOptions: []RichOption{{ID: "opt-ok", Text: "<b>OK</b>", Image: "ok.png"}, {ID: "opt-bad", Text: "Not OK"}},
+			},
+		}},
+	}}
+
+	rows := [][]string{
+		{"category", "question", "description", "type", "options"},
+		{"Fire safety", "Exits clear?", "Check all exits", "choice", "Not OK(red); Partly; OK(green)"},
+	}
+	categories, err := ParseTemplateSheet(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	MergeTemplateSheet(current, categories)
+
+	cat := categories[0]
+	q := cat.Questions[0]
+	if cat.CategoryName != "<b>Fire</b> safety" || q.Question != "<p>Exits clear?</p>" || q.Description != "<p>Check all exits</p>" {
+		t.Errorf("texts = %q, %q, %q, want the formatted texts", cat.CategoryName, q.Question, q.Description)
+	}
+
+	s := q.Settings
+	if got := []string{s.RichOptions[0].ID, s.RichOptions[2].ID}; !reflect.DeepEqual(got, []string{"opt-bad", "opt-ok"}) {
+		t.Errorf("kept option IDs = %v", got)
+	}
+	if partly := s.RichOptions[1].ID; partly == "opt-ok" || partly == "opt-bad" || partly == "" {
+		t.Errorf("new option ID = %q", partly)
+	}
+	if s.RichOptions[2].Text != "<b>OK</b>" || s.RichOptions[2].Image != "ok.png" {
+		t.Errorf("kept option = %+v", s.RichOptions[2])
+	}
+	if want := []string{"opt-bad", s.RichOptions[1].ID, "opt-ok"}; !reflect.DeepEqual(s.Answer, want) {
+		t.Errorf("answer = %v, want %v", s.Answer, want)
+	}
+	if got := s.Styling.Options["opt-bad"].BackgroundColor; got != OptionColors["red"] {
+		t.Errorf("styling of opt-bad = %q", got)
+	}
+	if len(s.Styling.Options) != 3 {
+		t.Errorf("styling keys = %v", s.Styling.Options)
+	}
+}
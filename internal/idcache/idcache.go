@@ -0,0 +1,227 @@
+// Package idcache keeps a local index of document IDs and the project (database)
+// they live in, so commands can resolve IDs without searching every project.
+package idcache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTTL is how long an entry is trusted after it was last seen
+const DefaultTTL = 30 * 24 * time.Hour
+
+// Entry maps a document to the project it lives in
+type Entry struct {
+	Kind     string    `json:"kind"` // ticket, audit, file or map
+	Database string    `json:"database"`
+	ID       string    `json:"id"`
+	HumanID  string    `json:"humanId"`
+	Label    string    `json:"label,omitempty"`
+	Seen     time.Time `json:"seen"`
+}
+
+// Stats summarizes the contents of the cache
+type Stats struct {
+	Path      string         `json:"path"`
+	SizeBytes int64          `json:"sizeBytes"`
+	Entries   int            `json:"entries"`
+	Expired   int            `json:"expired"`
+	ByKind    map[string]int `json:"byKind"`
+	Oldest    *time.Time     `json:"oldest,omitempty"`
+	Newest    *time.Time     `json:"newest,omitempty"`
+	TTL       string         `json:"ttl"`
+}
+
+// Cache is an on-disk ID index. It is not safe for concurrent use.
+type Cache struct {
+	path    string
+	ttl     time.Duration
+	entries map[string]Entry
+	now     func() time.Time
+}
+
+// DefaultPath returns the location of the cache file: the user cache directory
+// (e.g. ~/.cache/edcontrols-cli/ids.json), or ~/.config/edcontrols-cli/ids.json
+// when no cache directory is available.
+func DefaultPath() string {
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "edcontrols-cli", "ids.json")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "edcontrols-cli", "ids.json")
+	}
+	return ""
+}
+
+// Open loads the cache at path. A missing or unreadable cache file results in an
+// empty cache; the cache is an optimization and must never make a command fail.
+func Open(path string, ttl time.Duration) *Cache {
+	c := &Cache{
+		path:    path,
+		ttl:     ttl,
+		entries: make(map[string]Entry),
+		now:     time.Now,
+	}
+
+	if path == "" {
+		return c
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return c
+	}
+	for _, e := range entries {
+		c.entries[key(e.Kind, e.ID)] = e
+	}
+	return c
+}
+
+func key(kind, id string) string {
+	return kind + "|" + id
+}
+
+// Path returns the location of the cache file
+func (c *Cache) Path() string {
+	return c.path
+}
+
+// Add records (or refreshes) the project a document lives in
+func (c *Cache) Add(e Entry) {
+	if e.Kind == "" || e.Database == "" || e.ID == "" {
+		return
+	}
+	e.HumanID = strings.ToUpper(e.HumanID)
+	e.Seen = c.now().UTC()
+	c.entries[key(e.Kind, e.ID)] = e
+}
+
+// Remove forgets a document, e.g. after it was deleted
+func (c *Cache) Remove(kind, id string) {
+	delete(c.entries, key(kind, id))
+}
+
+// LookupID returns the entry for a full CouchDB ID
+func (c *Cache) LookupID(kind, id string) (Entry, bool) {
+	e, ok := c.entries[key(kind, id)]
+	if !ok || c.expired(e) {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// LookupHumanID returns all unexpired entries with the given human ID.
+// When database is set, only entries in that project are returned.
+func (c *Cache) LookupHumanID(kind, humanID, database string) []Entry {
+	humanID = strings.ToUpper(humanID)
+	var result []Entry
+	for _, e := range c.entries {
+		if e.Kind != kind || e.HumanID != humanID || c.expired(e) {
+			continue
+		}
+		if database != "" && e.Database != database {
+			continue
+		}
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Database+result[i].ID < result[j].Database+result[j].ID
+	})
+	return result
+}
+
+func (c *Cache) expired(e Entry) bool {
+	return c.ttl > 0 && c.now().Sub(e.Seen) > c.ttl
+}
+
+// Save writes the cache to disk, dropping expired entries
+func (c *Cache) Save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	entries := make([]Entry, 0, len(c.entries))
+	for k, e := range c.entries {
+		if c.expired(e) {
+			delete(c.entries, k)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return key(entries[i].Kind, entries[i].ID) < key(entries[j].Kind, entries[j].ID)
+	})
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("encoding cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	// Write to a temporary file first so a concurrent reader never sees a partial file
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("writing cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("writing cache: %w", err)
+	}
+	return nil
+}
+
+// Clear removes all entries and deletes the cache file
+func (c *Cache) Clear() error {
+	c.entries = make(map[string]Entry)
+	if c.path == "" {
+		return nil
+	}
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing cache: %w", err)
+	}
+	return nil
+}
+
+// Stats returns a summary of the cache contents
+func (c *Cache) Stats() Stats {
+	stats := Stats{
+		Path:   c.path,
+		ByKind: make(map[string]int),
+		TTL:    c.ttl.String(),
+	}
+
+	if fi, err := os.Stat(c.path); err == nil {
+		stats.SizeBytes = fi.Size()
+	}
+
+	for _, e := range c.entries {
+		if c.expired(e) {
+			stats.Expired++
+			continue
+		}
+		stats.Entries++
+		stats.ByKind[e.Kind]++
+
+		seen := e.Seen
+		if stats.Oldest == nil || seen.Before(*stats.Oldest) {
+			stats.Oldest = &seen
+		}
+		if stats.Newest == nil || seen.After(*stats.Newest) {
+			stats.Newest = &seen
+		}
+	}
+
+	return stats
+}
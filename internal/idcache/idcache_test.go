@@ -0,0 +1,99 @@
+package idcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheLookup(t *testing.T) {
+	c := Open(filepath.Join(t.TempDir(), "ids.json"), time.Hour)
+	c.Add(Entry{Kind: "ticket", Database: "db1", ID: "aaaaaab554cc", HumanID: "cc455b"})
+	c.Add(Entry{Kind: "ticket", Database: "db2", ID: "bbbbbbb554cc", HumanID: "CC455B"})
+	c.Add(Entry{Kind: "audit", Database: "db1", ID: "cccccc123456", HumanID: "654321"})
+
+	tests := []struct {
+		name     string
+		kind     string
+		humanID  string
+		database string
+		want     int
+	}{
+		{name: "all projects", kind: "ticket", humanID: "cc455b", want: 2},
+		{name: "one project", kind: "ticket", humanID: "CC455B", database: "db2", want: 1},
+		{name: "other kind", kind: "audit", humanID: "CC455B", want: 0},
+		{name: "unknown", kind: "ticket", humanID: "ZZZZZZ", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.LookupHumanID(tt.kind, tt.humanID, tt.database)
+			if len(got) != tt.want {
+				t.Errorf("LookupHumanID() returned %d entries, want %d", len(got), tt.want)
+			}
+		})
+	}
+
+	if e, ok := c.LookupID("audit", "cccccc123456"); !ok || e.Database != "db1" {
+		t.Errorf("LookupID() = %+v, %v", e, ok)
+	}
+	c.Remove("audit", "cccccc123456")
+	if _, ok := c.LookupID("audit", "cccccc123456"); ok {
+		t.Error("LookupID() found a removed entry")
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := Open(filepath.Join(t.TempDir(), "ids.json"), time.Hour)
+	c.now = func() time.Time { return now }
+	c.Add(Entry{Kind: "map", Database: "db1", ID: "map1", HumanID: "1PAM"})
+
+	now = now.Add(2 * time.Hour)
+	if _, ok := c.LookupID("map", "map1"); ok {
+		t.Error("LookupID() returned an expired entry")
+	}
+	if stats := c.Stats(); stats.Entries != 0 || stats.Expired != 1 {
+		t.Errorf("Stats() = %d entries, %d expired", stats.Entries, stats.Expired)
+	}
+}
+
+func TestCacheSaveAndClear(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "ids.json")
+	c := Open(path, DefaultTTL)
+	c.Add(Entry{Kind: "file", Database: "db1", ID: "file1", HumanID: "1ELIF", Label: "plan.pdf"})
+	if err := c.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	reopened := Open(path, DefaultTTL)
+	e, ok := reopened.LookupID("file", "file1")
+	if !ok || e.Database != "db1" || e.Label != "plan.pdf" {
+		t.Fatalf("LookupID() after reload = %+v, %v", e, ok)
+	}
+	if stats := reopened.Stats(); stats.Entries != 1 || stats.ByKind["file"] != 1 || stats.SizeBytes == 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+
+	if err := reopened.Clear(); err != nil {
+		t.Fatalf("Clear() error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("cache file still exists after Clear()")
+	}
+	if err := reopened.Clear(); err != nil {
+		t.Errorf("Clear() on a missing file: %v", err)
+	}
+}
+
+func TestOpenCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	c := Open(path, DefaultTTL)
+	if stats := c.Stats(); stats.Entries != 0 {
+		t.Errorf("corrupt cache loaded %d entries", stats.Entries)
+	}
+}
@@ -0,0 +1,115 @@
+package report
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"dataURI": func(img Image) template.URL {
+		return template.URL("data:" + img.ContentType + ";base64," + base64.StdEncoding.EncodeToString(img.Data))
+	},
+	"compliance": ComplianceText,
+	"counts": func(s api.CategoryScore) string {
+		return fmt.Sprintf("%d yes, %d no, %d n.a., %d unanswered", s.Yes, s.No, s.NA, s.Unanswered)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  body { font-family: Helvetica, Arial, sans-serif; color: #222; margin: 2em auto; max-width: 900px; }
+  header { display: flex; justify-content: space-between; align-items: flex-start; border-bottom: 3px solid #1f4e79; padding-bottom: 1em; }
+  header img { max-height: 60px; }
+  h1 { margin: 0 0 .3em 0; color: #1f4e79; }
+  h2 { color: #1f4e79; border-bottom: 1px solid #ccc; padding-bottom: .2em; margin-top: 1.8em; }
+  h2 .score { float: right; font-size: .8em; color: #555; }
+  table { width: 100%; border-collapse: collapse; }
+  th, td { text-align: left; padding: .4em .6em; border-bottom: 1px solid #e0e0e0; vertical-align: top; }
+  th { background: #f2f5f8; }
+  .meta td:first-child { color: #666; width: 10em; }
+  .num { width: 2.5em; color: #666; }
+  .answer { width: 30%; }
+  .description { color: #666; font-size: .9em; }
+  .unanswered { color: #999; font-style: italic; }
+  .summary { font-size: 1.1em; }
+  .photos { display: grid; grid-template-columns: repeat(2, 1fr); gap: 1em; }
+  .photos figure { margin: 0; }
+  .photos img { width: 100%; border: 1px solid #ddd; }
+  .photos figcaption { font-size: .8em; color: #666; }
+  .signature { margin-top: 3em; display: flex; gap: 4em; }
+  .signature div { border-top: 1px solid #222; width: 16em; padding-top: .3em; color: #666; }
+  footer { margin-top: 2em; font-size: .8em; color: #999; }
+</style>
+</head>
+<body>
+<header>
+  <div>
+    <h1>{{.Title}}</h1>
+    <div>Audit {{.AuditID}}</div>
+  </div>
+  {{with .Logo}}<img src="{{dataURI .}}" alt="logo">{{end}}
+</header>
+
+<table class="meta">
+  {{with .Project}}<tr><td>Project</td><td>{{.}}</td></tr>{{end}}
+  {{with .Template}}<tr><td>Template</td><td>{{.}}</td></tr>{{end}}
+  {{with .Auditor}}<tr><td>Auditor</td><td>{{.}}</td></tr>{{end}}
+  {{with .Responsible}}<tr><td>Responsible</td><td>{{.}}</td></tr>{{end}}
+  {{with .Status}}<tr><td>Status</td><td>{{.}}</td></tr>{{end}}
+  {{with .Created}}<tr><td>Created</td><td>{{.}}</td></tr>{{end}}
+  {{with .Due}}<tr><td>Due</td><td>{{.}}</td></tr>{{end}}
+  {{with .Completed}}<tr><td>Completed</td><td>{{.}}</td></tr>{{end}}
+</table>
+
+<h2>Summary</h2>
+<p class="summary">Compliance: <strong>{{compliance .Score.Total}}</strong> ({{counts .Score.Total}})</p>
+<table>
+  <tr><th>Category</th><th>Compliance</th><th>Yes</th><th>No</th><th>N.A.</th><th>Unanswered</th></tr>
+  {{range .Score.Categories}}<tr><td>{{.Category}}</td><td>{{compliance .}}</td><td>{{.Yes}}</td><td>{{.No}}</td><td>{{.NA}}</td><td>{{.Unanswered}}</td></tr>
+  {{end}}
+</table>
+
+{{range .Categories}}
+<h2>{{.Name}} <span class="score">{{compliance .Score}}</span></h2>
+<table>
+  {{range .Rows}}<tr>
+    <td class="num">{{.Number}}</td>
+    <td>{{.Question}}{{with .Description}}<div class="description">{{.}}</div>{{end}}</td>
+    {{if .Answer}}<td class="answer" style="{{with .Background}}background-color: {{.}};{{end}}{{with .Color}} color: {{.}};{{end}}">{{.Answer}}</td>
+    {{else}}<td class="answer unanswered">No answer</td>{{end}}
+  </tr>
+  {{end}}
+</table>
+{{end}}
+
+{{if .Photos}}
+<h2>Photos</h2>
+<div class="photos">
+  {{range .Photos}}<figure><img src="{{dataURI .}}" alt="{{.Name}}"><figcaption>{{.Name}}</figcaption></figure>
+  {{end}}
+</div>
+{{end}}
+
+<div class="signature">
+  <div>Auditor{{with .Auditor}}: {{.}}{{end}}</div>
+  <div>Date{{with .Completed}}: {{.}}{{end}}</div>
+</div>
+
+<footer>Generated {{.Generated.Format "2006-01-02 15:04"}}</footer>
+</body>
+</html>
+`))
+
+// WriteHTML renders the report as a self-contained HTML page with embedded photos
+func WriteHTML(w io.Writer, r *Report) error {
+	if err := htmlTemplate.Execute(w, r); err != nil {
+		return fmt.Errorf("rendering HTML report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	pdfMargin     = 15.0
+	pdfLineHeight = 5.0
+)
+
+// brand colour used for titles and table headers
+var pdfBrand = [3]int{31, 78, 121}
+
+// WritePDF renders the report as an A4 PDF
+func WritePDF(w io.Writer, r *Report) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetTitle(r.Title, true)
+	pdf.SetCreator("EdControls CLI", true)
+
+	// Core fonts are cp1252; translate UTF-8 text
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-10)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(150, 150, 150)
+		pdf.CellFormat(0, 4, tr(fmt.Sprintf("%s - audit %s", r.Title, r.AuditID)), "", 0, "L", false, 0, "")
+		pdf.SetX(pdfMargin)
+		pdf.CellFormat(0, 4, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	pdf.AliasNbPages("")
+	pdf.AddPage()
+
+	pageWidth, _ := pdf.GetPageSize()
+	contentWidth := pageWidth - 2*pdfMargin
+
+	// Header with title and logo
+	if r.Logo != nil {
+		if name, ok := registerImage(pdf, *r.Logo); ok {
+			pdf.ImageOptions(name, pageWidth-pdfMargin-40, pdfMargin, 40, 0, false, gofpdf.ImageOptions{}, 0, "")
+		}
+	}
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.SetTextColor(pdfBrand[0], pdfBrand[1], pdfBrand[2])
+	pdf.MultiCell(contentWidth-45, 8, tr(r.Title), "", "L", false)
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 6, "Audit "+r.AuditID, "", 1, "L", false, 0, "")
+	pdf.SetDrawColor(pdfBrand[0], pdfBrand[1], pdfBrand[2])
+	pdf.SetLineWidth(0.8)
+	pdf.Line(pdfMargin, pdf.GetY()+2, pageWidth-pdfMargin, pdf.GetY()+2)
+	pdf.SetLineWidth(0.2)
+	pdf.Ln(6)
+
+	// Audit details
+	pdf.SetTextColor(34, 34, 34)
+	for _, field := range [][2]string{
+		{"Project", r.Project},
+		{"Template", r.Template},
+		{"Auditor", r.Auditor},
+		{"Responsible", r.Responsible},
+		{"Status", r.Status},
+		{"Created", r.Created},
+		{"Due", r.Due},
+		{"Completed", r.Completed},
+	} {
+		if field[1] == "" {
+			continue
+		}
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.SetTextColor(100, 100, 100)
+		pdf.CellFormat(35, 6, field[0], "", 0, "L", false, 0, "")
+		pdf.SetTextColor(34, 34, 34)
+		pdf.CellFormat(0, 6, tr(field[1]), "", 1, "L", false, 0, "")
+	}
+
+	// Score summary
+	pdfHeading(pdf, tr, "Summary", "")
+	pdf.SetFont("Helvetica", "", 11)
+	total := r.Score.Total
+	pdf.MultiCell(0, 6, fmt.Sprintf("Compliance: %s (%d yes, %d no, %d n.a., %d unanswered)",
+		ComplianceText(total), total.Yes, total.No, total.NA, total.Unanswered), "", "L", false)
+	pdf.Ln(2)
+
+	widths := []float64{contentWidth - 100, 28, 16, 16, 16, 24}
+	pdfTableHeader(pdf, widths, []string{"Category", "Compliance", "Yes", "No", "N.A.", "Unanswered"})
+	pdf.SetFont("Helvetica", "", 9)
+	for _, cs := range r.Score.Categories {
+		values := []string{tr(cs.Category), ComplianceText(cs), strconv.Itoa(cs.Yes), strconv.Itoa(cs.No), strconv.Itoa(cs.NA), strconv.Itoa(cs.Unanswered)}
+		for i, v := range values {
+			pdf.CellFormat(widths[i], 6, truncateText(pdf, v, widths[i]-2), "B", 0, "L", false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	// Questions per category
+	for _, category := range r.Categories {
+		pdfHeading(pdf, tr, category.Name, ComplianceText(category.Score))
+		for _, row := range category.Rows {
+			pdfQuestionRow(pdf, tr, contentWidth, row)
+		}
+	}
+
+	// Photos, two per row
+	if len(r.Photos) > 0 {
+		pdfHeading(pdf, tr, "Photos", "")
+		pdfPhotos(pdf, tr, contentWidth, r.Photos)
+	}
+
+	// Signature block
+	pdf.Ln(15)
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+20 > pageHeight-pdfMargin {
+		pdf.AddPage()
+	}
+	y := pdf.GetY() + 12
+	pdf.SetDrawColor(34, 34, 34)
+	pdf.Line(pdfMargin, y, pdfMargin+70, y)
+	pdf.Line(pdfMargin+90, y, pdfMargin+160, y)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.SetXY(pdfMargin, y+1)
+	pdf.CellFormat(90, 5, tr("Auditor"+prefixed(": ", r.Auditor)), "", 0, "L", false, 0, "")
+	pdf.CellFormat(70, 5, "Date"+prefixed(": ", r.Completed), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.CellFormat(0, 8, "Generated "+r.Generated.Format("2006-01-02 15:04"), "", 1, "L", false, 0, "")
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("rendering PDF report: %w", err)
+	}
+	return nil
+}
+
+// pdfPhotos draws photos in a grid of two columns with their names as captions
+func pdfPhotos(pdf *gofpdf.Fpdf, tr func(string) string, contentWidth float64, photos []Image) {
+	const gap = 6.0
+	const maxHeight = 110.0
+	photoWidth := (contentWidth - gap) / 2
+
+	type placed struct {
+		name    string
+		caption string
+		width   float64
+		height  float64
+	}
+	var images []placed
+	for _, photo := range photos {
+		name, ok := registerImage(pdf, photo)
+		if !ok {
+			continue
+		}
+		info := pdf.GetImageInfo(name)
+		width, height := photoWidth, photoWidth*info.Height()/info.Width()
+		if height > maxHeight {
+			width, height = width*maxHeight/height, maxHeight
+		}
+		images = append(images, placed{name: name, caption: tr(photo.Name), width: width, height: height})
+	}
+
+	_, pageHeight := pdf.GetPageSize()
+	for i := 0; i < len(images); i += 2 {
+		row := images[i:]
+		if len(row) > 2 {
+			row = row[:2]
+		}
+		rowHeight := 0.0
+		for _, img := range row {
+			if img.height > rowHeight {
+				rowHeight = img.height
+			}
+		}
+		if pdf.GetY()+rowHeight+6 > pageHeight-pdfMargin {
+			pdf.AddPage()
+		}
+
+		y := pdf.GetY()
+		for j, img := range row {
+			x := pdfMargin + float64(j)*(photoWidth+gap)
+			pdf.ImageOptions(img.name, x, y, img.width, img.height, false, gofpdf.ImageOptions{}, 0, "")
+			pdf.SetXY(x, y+img.height+1)
+			pdf.SetFont("Helvetica", "", 8)
+			pdf.SetTextColor(100, 100, 100)
+			pdf.CellFormat(photoWidth, 4, truncateText(pdf, img.caption, photoWidth), "", 0, "L", false, 0, "")
+		}
+		pdf.SetXY(pdfMargin, y+rowHeight+8)
+	}
+	pdf.SetTextColor(34, 34, 34)
+}
+
+// pdfHeading starts a section, with an optional score on the right
+func pdfHeading(pdf *gofpdf.Fpdf, tr func(string) string, title, score string) {
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+25 > pageHeight-pdfMargin {
+		pdf.AddPage()
+	}
+	pdf.Ln(6)
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.SetTextColor(pdfBrand[0], pdfBrand[1], pdfBrand[2])
+	pdf.CellFormat(140, 8, tr(title), "B", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 8, score, "B", 1, "R", false, 0, "")
+	pdf.Ln(2)
+	pdf.SetTextColor(34, 34, 34)
+}
+
+// pdfTableHeader draws a table header row
+func pdfTableHeader(pdf *gofpdf.Fpdf, widths []float64, titles []string) {
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(242, 245, 248)
+	for i, title := range titles {
+		pdf.CellFormat(widths[i], 6, title, "B", 0, "L", true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// pdfQuestionRow draws a question with its answer; the answer cell uses the styling colours
+func pdfQuestionRow(pdf *gofpdf.Fpdf, tr func(string) string, contentWidth float64, row Row) {
+	const numWidth = 8.0
+	answerWidth := contentWidth * 0.32
+	questionWidth := contentWidth - numWidth - answerWidth
+
+	question := tr(row.Question)
+	description := tr(row.Description)
+	answer := tr(row.Answer)
+	if answer == "" {
+		answer = "No answer"
+	}
+
+	pdf.SetFont("Helvetica", "", 10)
+	questionLines := pdf.SplitLines([]byte(question), questionWidth-2)
+	pdf.SetFont("Helvetica", "", 8)
+	descriptionLines := pdf.SplitLines([]byte(description), questionWidth-2)
+	if description == "" {
+		descriptionLines = nil
+	}
+	pdf.SetFont("Helvetica", "", 10)
+	answerLines := pdf.SplitLines([]byte(answer), answerWidth-4)
+
+	height := float64(len(questionLines))*pdfLineHeight + float64(len(descriptionLines))*4
+	if h := float64(len(answerLines)) * pdfLineHeight; h > height {
+		height = h
+	}
+	height += 3
+
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+height > pageHeight-pdfMargin {
+		pdf.AddPage()
+	}
+
+	x, y := pdf.GetXY()
+
+	// Answer background
+	if r, g, b, ok := parseHexColor(row.Background); ok && row.Answer != "" {
+		pdf.SetFillColor(r, g, b)
+		pdf.Rect(x+numWidth+questionWidth, y, answerWidth, height, "F")
+	}
+
+	pdf.SetTextColor(100, 100, 100)
+	pdf.SetXY(x, y+1.5)
+	pdf.CellFormat(numWidth, pdfLineHeight, strconv.Itoa(row.Number), "", 0, "L", false, 0, "")
+
+	pdf.SetTextColor(34, 34, 34)
+	for i, line := range questionLines {
+		pdf.SetXY(x+numWidth, y+1.5+float64(i)*pdfLineHeight)
+		pdf.CellFormat(questionWidth, pdfLineHeight, string(line), "", 0, "L", false, 0, "")
+	}
+	if len(descriptionLines) > 0 {
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(100, 100, 100)
+		top := y + 1.5 + float64(len(questionLines))*pdfLineHeight
+		for i, line := range descriptionLines {
+			pdf.SetXY(x+numWidth, top+float64(i)*4)
+			pdf.CellFormat(questionWidth, 4, string(line), "", 0, "L", false, 0, "")
+		}
+		pdf.SetFont("Helvetica", "", 10)
+	}
+
+	switch {
+	case row.Answer == "":
+		pdf.SetFont("Helvetica", "I", 10)
+		pdf.SetTextColor(150, 150, 150)
+	default:
+		if r, g, b, ok := parseHexColor(row.Color); ok {
+			pdf.SetTextColor(r, g, b)
+		} else {
+			pdf.SetTextColor(34, 34, 34)
+		}
+	}
+	for i, line := range answerLines {
+		pdf.SetXY(x+numWidth+questionWidth+2, y+1.5+float64(i)*pdfLineHeight)
+		pdf.CellFormat(answerWidth-4, pdfLineHeight, string(line), "", 0, "L", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(34, 34, 34)
+
+	pdf.SetDrawColor(224, 224, 224)
+	pdf.Line(x, y+height, x+contentWidth, y+height)
+	pdf.SetXY(x, y+height)
+}
+
+// registerImage adds an image to the PDF. Only JPEG, PNG and GIF are supported.
+func registerImage(pdf *gofpdf.Fpdf, img Image) (string, bool) {
+	var imageType string
+	switch img.ContentType {
+	case "image/jpeg", "image/jpg":
+		imageType = "JPG"
+	case "image/png":
+		imageType = "PNG"
+	case "image/gif":
+		imageType = "GIF"
+	default:
+		return "", false
+	}
+
+	name := "img-" + img.Name
+	info := pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: imageType}, bytes.NewReader(img.Data))
+	if info == nil || !pdf.Ok() || info.Width() == 0 {
+		// A broken image must not spoil the whole report
+		pdf.ClearError()
+		return "", false
+	}
+	return name, true
+}
+
+// parseHexColor parses "#rgb" or "#rrggbb"
+func parseHexColor(s string) (int, int, int, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff), true
+}
+
+// truncateText shortens s to fit in width with the current font
+func truncateText(pdf *gofpdf.Fpdf, s string, width float64) string {
+	if pdf.GetStringWidth(s) <= width {
+		return s
+	}
+	for len(s) > 0 && pdf.GetStringWidth(s+"...") > width {
+		s = s[:len(s)-1]
+	}
+	return s + "..."
+}
+
+func prefixed(prefix, s string) string {
+	if s == "" {
+		return ""
+	}
+	return prefix + s
+}
@@ -0,0 +1,131 @@
+// Package report renders audit reports as HTML or PDF.
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+// Report is the content of an audit report, independent of the output format
+type Report struct {
+	Title       string
+	AuditID     string // Human ID
+	Project     string
+	Template    string
+	Auditor     string
+	Responsible string
+	Status      string
+	Created     string
+	Due         string
+	Completed   string
+	Logo        *Image
+	Categories  []Category
+	Photos      []Image
+	Score       api.AuditScore
+	Generated   time.Time
+}
+
+// Category is a section of the report with the questions of one audit category
+type Category struct {
+	Name  string
+	Score api.CategoryScore
+	Rows  []Row
+}
+
+// Row is a question and its answer
+type Row struct {
+	Number      int
+	Question    string
+	Description string
+	Answer      string
+	Background  string // Answer colours from the question styling, e.g. "#4caf50"
+	Color       string
+}
+
+// Image is an embedded picture (attachment photo or logo)
+type Image struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// New builds a report from an audit. Project and template names, the logo and
+// photos are filled in by the caller.
+func New(audit *api.Audit, humanID string) *Report {
+	r := &Report{
+		Title:     audit.Name,
+		AuditID:   humanID,
+		Template:  firstNonEmpty(audit.TemplateName, audit.Template),
+		Status:    audit.Status,
+		Score:     api.ScoreAudit(audit),
+		Generated: time.Now(),
+	}
+
+	if audit.Author != nil {
+		r.Auditor = audit.Author.Email
+	}
+	if audit.Participants != nil && audit.Participants.Responsible != nil {
+		r.Responsible = audit.Participants.Responsible.Email
+	}
+	if audit.Dates != nil {
+		r.Created = formatDate(audit.Dates.CreationDate)
+		r.Due = formatDate(audit.Dates.DueDate)
+		r.Completed = formatDate(audit.Dates.CompletionDate)
+	}
+
+	for i, category := range audit.Questions {
+		c := Category{Name: api.PlainText(category.CategoryName)}
+		if i < len(r.Score.Categories) {
+			c.Score = r.Score.Categories[i]
+		}
+		for j, q := range category.Questions {
+			row := Row{
+				Number:      j + 1,
+				Question:    api.PlainText(q.Question),
+				Description: api.PlainText(q.Description),
+				Answer:      api.AnswerText(q.Settings, q.Answer),
+			}
+			if style := api.AnswerStyle(q.Settings, q.Answer); style != nil {
+				row.Background = style.BackgroundColor
+				row.Color = style.Color
+			}
+			c.Rows = append(c.Rows, row)
+		}
+		r.Categories = append(r.Categories, c)
+	}
+
+	return r
+}
+
+// ComplianceText returns the compliance percentage of a score, or "-" without yes/no answers
+func ComplianceText(score api.CategoryScore) string {
+	pct, ok := score.Compliance()
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", pct)
+}
+
+// formatDate shortens an ISO 8601 timestamp to "2006-01-02 15:04" in local time
+func formatDate(value string) string {
+	if value == "" {
+		return ""
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.000Z"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Local().Format("2006-01-02 15:04")
+		}
+	}
+	return value
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
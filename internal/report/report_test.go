@@ -0,0 +1,120 @@
+package report
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/dutchview/edcontrols-cli/internal/api"
+)
+
+func testAudit() *api.Audit {
+	styled := &api.QuestionSettings{
+		AnswerType: "yesnona",
+		Styling: &api.QuestionStyling{Options: map[string]api.StylingOption{
+			"yes": {BackgroundColor: "#4caf50", Color: "#ffffff"},
+		}},
+	}
+	return &api.Audit{
+		Name:   "Site inspection – week 12",
+		Status: "completed",
+		Author: &api.Person{Email: "inspector@example.com"},
+		Dates:  &api.AuditDates{CreationDate: "2026-03-16T08:00:00.000Z", CompletionDate: "2026-03-16T10:30:00.000Z"},
+		Questions: []api.QuestionCategory{
+			{CategoryName: "Fire safety", Questions: []api.Question{
+				{Question: "Extinguisher present?", Answer: []interface{}{"yes"}, Settings: styled},
+				{Question: "<p>Emergency exits free?</p>", Description: "<p>Check <b>all</b> floors</p>", Answer: []interface{}{"no"}, Settings: styled},
+				{Question: "Remarks", Settings: &api.QuestionSettings{AnswerType: "freetext"}},
+			}},
+		},
+	}
+}
+
+func testPNG(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	for x := 0; x < 40; x++ {
+		img.Set(x, 10, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestNew(t *testing.T) {
+	r := New(testAudit(), "708739")
+
+	if r.Auditor != "inspector@example.com" || r.Completed == "" {
+		t.Errorf("header = %+v", r)
+	}
+	if len(r.Categories) != 1 || len(r.Categories[0].Rows) != 3 {
+		t.Fatalf("categories = %+v", r.Categories)
+	}
+	first := r.Categories[0].Rows[0]
+	if first.Answer != "Yes" || first.Background != "#4caf50" || first.Color != "#ffffff" {
+		t.Errorf("row = %+v", first)
+	}
+	// Texts stored as HTML are shown as plain text
+	second := r.Categories[0].Rows[1]
+	if second.Question != "Emergency exits free?" || second.Description != "Check all floors" {
+		t.Errorf("row = %+v", second)
+	}
+	if got := ComplianceText(r.Score.Total); got != "50%" {
+		t.Errorf("compliance = %s, want 50%%", got)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	r := New(testAudit(), "708739")
+	r.Photos = []Image{{Name: "photo.png", ContentType: "image/png", Data: testPNG(t)}}
+
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, r); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+	for _, want := range []string{"Site inspection", "background-color: #4caf50", "No answer", "data:image/png;base64,", "Check all floors"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML report does not contain %q", want)
+		}
+	}
+}
+
+func TestWritePDF(t *testing.T) {
+	r := New(testAudit(), "708739")
+	r.Photos = []Image{
+		{Name: "photo.png", ContentType: "image/png", Data: testPNG(t)},
+		{Name: "broken.png", ContentType: "image/png", Data: []byte("not an image")},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, r); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Error("output is not a PDF")
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	tests := []struct {
+		in      string
+		r, g, b int
+		ok      bool
+	}{
+		{in: "#4caf50", r: 0x4c, g: 0xaf, b: 0x50, ok: true},
+		{in: "#fff", r: 255, g: 255, b: 255, ok: true},
+		{in: "red", ok: false},
+		{in: "", ok: false},
+	}
+	for _, tt := range tests {
+		r, g, b, ok := parseHexColor(tt.in)
+		if ok != tt.ok || r != tt.r || g != tt.g || b != tt.b {
+			t.Errorf("parseHexColor(%q) = %d, %d, %d, %v", tt.in, r, g, b, ok)
+		}
+	}
+}
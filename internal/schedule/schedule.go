@@ -0,0 +1,197 @@
+// Package schedule reads recurring audit schedules and works out which audits are due.
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTitle is the audit name used when a rule has no title
+const DefaultTitle = "{template} {period}"
+
+// File is a schedule file with one or more rules
+type File struct {
+	Schedules []Rule `yaml:"schedules"`
+}
+
+// Rule describes audits that are created from a template at a regular interval
+type Rule struct {
+	Name        string   `yaml:"name"`
+	Template    string   `yaml:"template"` // Template name or ID, looked up in each project
+	Projects    []string `yaml:"projects"`
+	Every       string   `yaml:"every"`        // day, weekday, monday..sunday or month
+	DayOfMonth  int      `yaml:"day_of_month"` // For monthly rules; default 1, clamped to the month length
+	Title       string   `yaml:"title"`        // Audit name, see Rule.AuditName
+	Responsible string   `yaml:"responsible"`
+	Due         string   `yaml:"due"` // Offset from the scheduled date, e.g. 2d, 1w, 1mo
+	Tags        []string `yaml:"tags"`
+}
+
+// Occurrence is the scheduled date of a rule and the period it belongs to
+type Occurrence struct {
+	Date   time.Time
+	Period string // 2026-03-16 (daily), 2026-W12 (weekly) or 2026-03 (monthly)
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// Load reads and validates a schedule file
+func Load(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading schedule file: %w", err)
+	}
+
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing schedule file: %w", err)
+	}
+	if len(f.Schedules) == 0 {
+		return nil, fmt.Errorf("schedule file has no schedules")
+	}
+
+	var errs []string
+	for i, rule := range f.Schedules {
+		if err := rule.Validate(); err != nil {
+			errs = append(errs, fmt.Sprintf("schedule %d (%s): %v", i+1, rule.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid schedule file:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return f.Schedules, nil
+}
+
+// Validate checks that a rule is complete. The title must contain {period} or
+// {date}, so that audits of different periods get different names.
+func (r Rule) Validate() error {
+	if r.Template == "" {
+		return fmt.Errorf("template is required")
+	}
+	if len(r.Projects) == 0 {
+		return fmt.Errorf("at least one project is required")
+	}
+	every := strings.ToLower(r.Every)
+	if _, ok := weekdays[every]; !ok && every != "day" && every != "weekday" && every != "month" {
+		return fmt.Errorf("every must be day, weekday, a day of the week or month, got %q", r.Every)
+	}
+	if r.DayOfMonth < 0 || r.DayOfMonth > 31 {
+		return fmt.Errorf("day_of_month must be between 1 and 31")
+	}
+	if r.Title != "" && !strings.Contains(r.Title, "{period}") && !strings.Contains(r.Title, "{date}") {
+		return fmt.Errorf("title must contain {period} or {date}")
+	}
+	if r.Due != "" {
+		if _, err := ParseOffset(r.Due, time.Now()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Occurrence returns the latest scheduled date of the rule on or before day, so an
+// audit that was missed earlier in the period is still created.
+func (r Rule) Occurrence(day time.Time) Occurrence {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	every := strings.ToLower(r.Every)
+
+	switch every {
+	case "day":
+		return Occurrence{Date: day, Period: day.Format("2006-01-02")}
+	case "weekday":
+		for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			day = day.AddDate(0, 0, -1)
+		}
+		return Occurrence{Date: day, Period: day.Format("2006-01-02")}
+	case "month":
+		dom := r.DayOfMonth
+		if dom == 0 {
+			dom = 1
+		}
+		date := monthDay(day.Year(), day.Month(), dom, day.Location())
+		if date.After(day) {
+			date = monthDay(day.Year(), day.Month()-1, dom, day.Location())
+		}
+		return Occurrence{Date: date, Period: date.Format("2006-01")}
+	}
+
+	offset := (int(day.Weekday()) - int(weekdays[every]) + 7) % 7
+	date := day.AddDate(0, 0, -offset)
+	year, week := date.ISOWeek()
+	return Occurrence{Date: date, Period: fmt.Sprintf("%d-W%02d", year, week)}
+}
+
+// monthDay returns the given day of a month, or the last day if the month is shorter
+func monthDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, loc)
+	if day > last.Day() {
+		return last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// AuditName returns the name of the audit for an occurrence. The placeholders
+// {template}, {period}, {date}, {year}, {month} and {week} are replaced.
+func (r Rule) AuditName(templateName string, o Occurrence) string {
+	title := r.Title
+	if title == "" {
+		title = DefaultTitle
+	}
+	_, week := o.Date.ISOWeek()
+	return strings.NewReplacer(
+		"{template}", templateName,
+		"{period}", o.Period,
+		"{date}", o.Date.Format("2006-01-02"),
+		"{year}", strconv.Itoa(o.Date.Year()),
+		"{month}", o.Date.Format("01"),
+		"{week}", fmt.Sprintf("%02d", week),
+	).Replace(title)
+}
+
+// DueDate returns the due date of the audit for an occurrence (end of the day,
+// in UTC) or "" if the rule has no due offset
+func (r Rule) DueDate(o Occurrence) (string, error) {
+	if r.Due == "" {
+		return "", nil
+	}
+	due, err := ParseOffset(r.Due, o.Date)
+	if err != nil {
+		return "", err
+	}
+	due = time.Date(due.Year(), due.Month(), due.Day(), 23, 59, 59, 0, due.Location())
+	return due.UTC().Format("2006-01-02T15:04:05.000Z"), nil
+}
+
+var offsetRe = regexp.MustCompile(`^\+?(\d+)(d|w|mo|y)$`)
+
+// ParseOffset adds an offset such as "2d", "+1w", "1mo" or "1y" to from
+func ParseOffset(s string, from time.Time) (time.Time, error) {
+	m := offsetRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return time.Time{}, fmt.Errorf("invalid offset %q (use e.g. 2d, 1w, 1mo, 1y)", s)
+	}
+	n, _ := strconv.Atoi(m[1])
+	switch m[2] {
+	case "w":
+		return from.AddDate(0, 0, n*7), nil
+	case "mo":
+		return from.AddDate(0, n, 0), nil
+	case "y":
+		return from.AddDate(n, 0, 0), nil
+	}
+	return from.AddDate(0, 0, n), nil
+}
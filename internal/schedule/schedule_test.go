@@ -0,0 +1,141 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02", s, time.UTC)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestOccurrence(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       Rule
+		day        string
+		wantDate   string
+		wantPeriod string
+	}{
+		{name: "weekly on the day", rule: Rule{Every: "monday"}, day: "2026-03-16", wantDate: "2026-03-16", wantPeriod: "2026-W12"},
+		{name: "weekly later in the week", rule: Rule{Every: "Monday"}, day: "2026-03-19", wantDate: "2026-03-16", wantPeriod: "2026-W12"},
+		{name: "weekly before the day", rule: Rule{Every: "friday"}, day: "2026-03-16", wantDate: "2026-03-13", wantPeriod: "2026-W11"},
+		{name: "daily", rule: Rule{Every: "day"}, day: "2026-03-15", wantDate: "2026-03-15", wantPeriod: "2026-03-15"},
+		{name: "weekday on sunday", rule: Rule{Every: "weekday"}, day: "2026-03-15", wantDate: "2026-03-13", wantPeriod: "2026-03-13"},
+		{name: "monthly default", rule: Rule{Every: "month"}, day: "2026-03-16", wantDate: "2026-03-01", wantPeriod: "2026-03"},
+		{name: "monthly before the day", rule: Rule{Every: "month", DayOfMonth: 20}, day: "2026-03-16", wantDate: "2026-02-20", wantPeriod: "2026-02"},
+		{name: "monthly clamped", rule: Rule{Every: "month", DayOfMonth: 31}, day: "2026-03-16", wantDate: "2026-02-28", wantPeriod: "2026-02"},
+		{name: "monthly over new year", rule: Rule{Every: "month", DayOfMonth: 15}, day: "2026-01-10", wantDate: "2025-12-15", wantPeriod: "2025-12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := tt.rule.Occurrence(date(tt.day))
+			if got := o.Date.Format("2006-01-02"); got != tt.wantDate {
+				t.Errorf("date = %s, want %s", got, tt.wantDate)
+			}
+			if o.Period != tt.wantPeriod {
+				t.Errorf("period = %s, want %s", o.Period, tt.wantPeriod)
+			}
+		})
+	}
+}
+
+func TestAuditNameAndDueDate(t *testing.T) {
+	rule := Rule{Every: "monday", Title: "Safety walk {year} week {week} ({date})", Due: "+2d"}
+	o := rule.Occurrence(date("2026-03-18"))
+
+	if got, want := rule.AuditName("Safety walk", o), "Safety walk 2026 week 12 (2026-03-16)"; got != want {
+		t.Errorf("AuditName() = %q, want %q", got, want)
+	}
+	if got := (Rule{}).AuditName("Safety walk", o); got != "Safety walk 2026-W12" {
+		t.Errorf("default AuditName() = %q", got)
+	}
+
+	due, err := rule.DueDate(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if due != "2026-03-18T23:59:59.000Z" {
+		t.Errorf("DueDate() = %s", due)
+	}
+}
+
+func TestParseOffset(t *testing.T) {
+	from := date("2026-01-31")
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "2d", want: "2026-02-02"},
+		{in: "+1w", want: "2026-02-07"},
+		{in: "1y", want: "2027-01-31"},
+		{in: "0d", want: "2026-01-31"},
+		{in: "-2d", wantErr: true},
+		{in: "2 days", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseOffset(tt.in, from)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseOffset(%q) error = %v", tt.in, err)
+			continue
+		}
+		if err == nil && got.Format("2006-01-02") != tt.want {
+			t.Errorf("ParseOffset(%q) = %s, want %s", tt.in, got.Format("2006-01-02"), tt.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	rules, err := Load(write("ok.yaml", `
+schedules:
+  - name: Weekly safety walk
+    template: Safety walk
+    projects: [site_a, site_b]
+    every: monday
+    responsible: hse@example.com
+    due: 2d
+    tags: [weekly]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || len(rules[0].Projects) != 2 || rules[0].Tags[0] != "weekly" {
+		t.Errorf("rules = %+v", rules)
+	}
+
+	_, err = Load(write("bad.yaml", `
+schedules:
+  - template: Safety walk
+    every: fortnight
+    title: Safety walk
+  - projects: [site_a]
+    every: day
+    due: soon
+`))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"schedule 1", "at least one project", "schedule 2", "template is required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Package snapshot keeps local, versioned copies of audit templates so a template
+// can be compared with an earlier state after it was changed in EdControls.
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Snapshot is a stored copy of a template document
+type Snapshot struct {
+	Version    int                    `json:"version"`
+	TakenAt    time.Time              `json:"takenAt"`
+	Database   string                 `json:"database"`
+	TemplateID string                 `json:"templateId"`
+	Name       string                 `json:"name"`
+	Rev        string                 `json:"rev"`
+	Message    string                 `json:"message,omitempty"`
+	Document   map[string]interface{} `json:"document"`
+}
+
+// Store is a directory of snapshots, with one subdirectory per project and template
+type Store struct {
+	dir string
+	now func() time.Time
+}
+
+// DefaultDir returns the default snapshot directory,
+// ~/.config/edcontrols-cli/snapshots
+func DefaultDir() string {
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "edcontrols-cli", "snapshots")
+	}
+	return ""
+}
+
+// Open returns the store in dir
+func Open(dir string) *Store {
+	return &Store{dir: dir, now: time.Now}
+}
+
+func (s *Store) templateDir(database, templateID string) string {
+	return filepath.Join(s.dir, database, templateID)
+}
+
+// List returns the snapshots of a template, oldest first
+func (s *Store) List(database, templateID string) ([]Snapshot, error) {
+	dir := s.templateDir(database, templateID)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshots: %w", err)
+	}
+
+	var snapshots []Snapshot
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimSuffix(name, ".json")); err != nil {
+			continue
+		}
+		snap, err := Load(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, *snap)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Version < snapshots[j].Version
+	})
+	return snapshots, nil
+}
+
+// Get returns one version of a template; version 0 is the latest
+func (s *Store) Get(database, templateID string, version int) (*Snapshot, error) {
+	snapshots, err := s.List(database, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("no snapshots of template %s in %s (use templates snapshot)", templateID, database)
+	}
+	if version == 0 {
+		return &snapshots[len(snapshots)-1], nil
+	}
+	for i := range snapshots {
+		if snapshots[i].Version == version {
+			return &snapshots[i], nil
+		}
+	}
+	return nil, fmt.Errorf("template %s has no snapshot version %d", templateID, version)
+}
+
+// Save stores a template document as the next version. When the latest snapshot
+// has the same revision nothing is written and that snapshot is returned with
+// false.
+func (s *Store) Save(database, templateID string, doc map[string]interface{}, message string) (*Snapshot, bool, error) {
+	if s.dir == "" {
+		return nil, false, fmt.Errorf("no snapshot directory")
+	}
+
+	snapshots, err := s.List(database, templateID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	rev, _ := doc["_rev"].(string)
+	version := 1
+	if n := len(snapshots); n > 0 {
+		latest := snapshots[n-1]
+		if rev != "" && latest.Rev == rev {
+			return &latest, false, nil
+		}
+		version = latest.Version + 1
+	}
+
+	name, _ := doc["name"].(string)
+	snap := &Snapshot{
+		Version:    version,
+		TakenAt:    s.now().UTC(),
+		Database:   database,
+		TemplateID: templateID,
+		Name:       name,
+		Rev:        rev,
+		Message:    message,
+		Document:   doc,
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, false, fmt.Errorf("encoding snapshot: %w", err)
+	}
+
+	dir := s.templateDir(database, templateID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, false, fmt.Errorf("creating snapshot directory: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%d.json", version))
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return nil, false, fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return nil, false, fmt.Errorf("writing snapshot: %w", err)
+	}
+	return snap, true, nil
+}
+
+// Load reads a snapshot file
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parsing snapshot %s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+// Path returns the file of a snapshot version
+func (s *Store) Path(database, templateID string, version int) string {
+	return filepath.Join(s.templateDir(database, templateID), fmt.Sprintf("%d.json", version))
+}
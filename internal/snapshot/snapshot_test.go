@@ -0,0 +1,59 @@
+package snapshot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStoreVersions(t *testing.T) {
+	s := Open(t.TempDir())
+	s.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	doc := func(rev string) map[string]interface{} {
+		return map[string]interface{}{"_id": "tpl1", "_rev": rev, "name": "Safety"}
+	}
+
+	tests := []struct {
+		name        string
+		rev         string
+		wantVersion int
+		wantSaved   bool
+	}{
+		{name: "first", rev: "1-a", wantVersion: 1, wantSaved: true},
+		{name: "same revision", rev: "1-a", wantVersion: 1, wantSaved: false},
+		{name: "new revision", rev: "2-b", wantVersion: 2, wantSaved: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap, saved, err := s.Save("db1", "tpl1", doc(tt.rev), "")
+			if err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if snap.Version != tt.wantVersion || saved != tt.wantSaved {
+				t.Errorf("Save() = version %d, saved %v, want %d, %v", snap.Version, saved, tt.wantVersion, tt.wantSaved)
+			}
+		})
+	}
+
+	snapshots, err := s.List("db1", "tpl1")
+	if err != nil || len(snapshots) != 2 {
+		t.Fatalf("List() = %d snapshots, %v, want 2", len(snapshots), err)
+	}
+
+	latest, err := s.Get("db1", "tpl1", 0)
+	if err != nil || latest.Rev != "2-b" || latest.Name != "Safety" {
+		t.Errorf("Get(latest) = %+v, %v", latest, err)
+	}
+	if _, err := s.Get("db1", "tpl1", 5); err == nil {
+		t.Error("Get(5) expected an error")
+	}
+	if _, err := s.Get("db1", "other", 0); err == nil {
+		t.Error("Get() of a template without snapshots expected an error")
+	}
+
+	loaded, err := Load(s.Path("db1", "tpl1", 1))
+	if err != nil || loaded.Rev != "1-a" || loaded.Document["_id"] != "tpl1" {
+		t.Errorf("Load() = %+v, %v", loaded, err)
+	}
+}
@@ -0,0 +1,82 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	rows := [][]interface{}{
+		{"id", "name", "score"},
+		{"708739", "Fire & safety <week 12>", 3.5},
+		{"91A2C4", nil, 2, true},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "Safety walk: weekly", rows); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+
+		// Every part must be well-formed XML
+		dec := xml.NewDecoder(bytes.NewReader(data))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s: %v", f.Name, err)
+			}
+		}
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("missing %s", name)
+		}
+	}
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`,
+		`Fire &amp; safety &lt;week 12&gt;`,
+		`<c r="C2"><v>3.5</v></c>`,
+		`<c r="C3"><v>2</v></c>`,
+		`<c r="D3" t="b"><v>1</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet does not contain %s", want)
+		}
+	}
+	if strings.Contains(sheet, `r="B3"`) {
+		t.Error("nil cell was written")
+	}
+	if !strings.Contains(files["xl/workbook.xml"], `name="Safety walk_ weekly"`) {
+		t.Errorf("workbook = %s", files["xl/workbook.xml"])
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for index, want := range tests {
+		if got := ColumnName(index); got != want {
+			t.Errorf("ColumnName(%d) = %s, want %s", index, got, want)
+		}
+	}
+}
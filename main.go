@@ -14,18 +14,20 @@ var version = "1.5.0"
 
 var CLI struct {
 	// Global flags
-	Config string `short:"c" help:"Path to config file (.env format)" type:"path"`
-	Token  string `help:"Access token (overrides config file)" env:"EDCONTROLS_ACCESS_TOKEN"`
+	Config  string `short:"c" help:"Path to config file (.env format)" type:"path"`
+	Token   string `help:"Access token (overrides config file)" env:"EDCONTROLS_ACCESS_TOKEN"`
+	NoCache bool   `help:"Don't use the local ID cache" env:"EDCONTROLS_NO_CACHE"`
 
 	// Commands
 	Whoami    cmd.WhoamiCmd    `cmd:"" help:"Show current user info (-j for JSON)"`
 	Contracts cmd.ContractsCmd `cmd:"" help:"Manage contracts/clients (list, projects)"`
 	Projects  cmd.ProjectsCmd  `cmd:"" help:"Manage projects (list, get) with search and glacier support"`
-	Tickets   cmd.TicketsCmd   `cmd:"" help:"Manage tickets (list, get, update, assign, open, close, archive, unarchive, delete, attachments)"`
-	Audits    cmd.AuditsCmd    `cmd:"" help:"Manage audits (list, get, create, update, delete, attachments)"`
-	Templates cmd.TemplatesCmd `cmd:"" help:"Manage audit templates (list, get, create, update, publish, unpublish) and groups (list, get, create, update, delete)"`
+	Tickets   cmd.TicketsCmd   `cmd:"" help:"Manage tickets (list, get, create, update, assign, open, close, archive, unarchive, delete, attachments, comments, participants, tags, bulk, history)"`
+	Audits    cmd.AuditsCmd    `cmd:"" help:"Manage audits (list, get, create, update, answer, report, stats, findings, diff, export, import, start, complete, reopen, schedule, delete, attachments, participants, tags, history)"`
+	Templates cmd.TemplatesCmd `cmd:"" help:"Manage audit templates (list, get, create, update, publish, unpublish, tags, build, copy, diff, snapshot, lint, import, export) and groups (list, get, create, update, delete, copy)"`
 	Maps      cmd.MapsCmd      `cmd:"" help:"Manage maps/drawings (list, get, add, delete, tags) and groups (list)"`
 	Files     cmd.FilesCmd     `cmd:"" help:"Manage files (list, get, add, download, archive, unarchive, delete, tags, to-map) and groups (list, create)"`
+	Cache     cmd.CacheCmd     `cmd:"" help:"Manage the local ID cache (clear, stats)"`
 	Configure ConfigureCmd     `cmd:"" help:"Show configuration help and setup instructions"`
 }
 
@@ -56,8 +58,9 @@ func main() {
 
 	// Commands that don't need the API client
 	switch ctx.Command() {
-	case "configure":
-		err := ctx.Run()
+	case "configure", "cache clear", "cache stats", "templates build <file>", "templates lint <file-or-project>":
+		// Commands that only sometimes need the client get a nil one
+		err := ctx.Run((*api.Client)(nil))
 		ctx.FatalIfErrorf(err)
 		return
 	}
@@ -81,10 +84,15 @@ func main() {
 		cfg.Token = CLI.Token
 	}
 
+	if CLI.NoCache {
+		cmd.DisableIDCache()
+	}
+
 	// Create API client
 	client := api.NewClient(cfg)
 
 	// Run the command with the client
 	err = ctx.Run(client)
+	cmd.SaveIDCache()
 	ctx.FatalIfErrorf(err)
 }